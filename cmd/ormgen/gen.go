@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/issue9/orm/v6/fetch"
+	"github.com/issue9/orm/v6/internal/tags"
+)
+
+const exprPath = "github.com/issue9/orm/v6/sqlbuilder/expr"
+
+// 从 Go 类型中分析出的模型
+type model struct {
+	name    string
+	ptrRecv bool // TableName 是否为指针接收者
+	columns []*column
+}
+
+type column struct {
+	goName string
+	name   string
+	typ    types.Type
+}
+
+// 为 p 中的所有模型生成代码
+//
+// 返回值的键名为文件名，键值为文件内容。
+func generate(p *pkg) (map[string][]byte, error) {
+	models, err := parseModels(p.types)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte, len(models))
+	for _, m := range models {
+		data, err := m.render(p.types)
+		if err != nil {
+			return nil, err
+		}
+		files[strings.ToLower(m.name)+fileSuffix] = data
+	}
+	return files, nil
+}
+
+// 查找 p 中所有实现了 core.TableNamer 的结构体
+func parseModels(p *types.Package) ([]*model, error) {
+	models := make([]*model, 0, 10)
+
+	scope := p.Scope()
+	for _, name := range scope.Names() { // Names 返回的内容是有序的
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			continue
+		}
+
+		named, ok := tn.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 {
+			continue
+		}
+
+		st, ok := named.Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+
+		ptrRecv, ok := tableNamer(named)
+		if !ok {
+			continue
+		}
+
+		m := &model{name: name, ptrRecv: ptrRecv}
+		if err := m.parseColumns(st); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		models = append(models, m)
+	}
+
+	return models, nil
+}
+
+// 判断 t 是否实现了 core.TableNamer 接口
+//
+// ptrRecv 表示是否仅有指针实现了该接口。
+func tableNamer(t *types.Named) (ptrRecv, ok bool) {
+	isTableName := func(ms *types.MethodSet) bool {
+		sel := ms.Lookup(nil, "TableName")
+		if sel == nil {
+			return false
+		}
+
+		sig, ok := sel.Type().(*types.Signature)
+		if !ok || sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+			return false
+		}
+
+		basic, ok := sig.Results().At(0).Type().(*types.Basic)
+		return ok && basic.Kind() == types.String
+	}
+
+	if isTableName(types.NewMethodSet(t)) {
+		return false, true
+	}
+	return true, isTableName(types.NewMethodSet(types.NewPointer(t)))
+}
+
+// 与 internal/model 中的 parseColumns 采用相同的规则
+func (m *model) parseColumns(st *types.Struct) error {
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+
+		if field.Embedded() {
+			if s, ok := field.Type().Underlying().(*types.Struct); ok {
+				if err := m.parseColumns(s); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if unicode.IsLower(rune(field.Name()[0])) { // 忽略以小写字母开头的字段
+			continue
+		}
+
+		tag := reflect.StructTag(st.Tag(i)).Get(fetch.Tag)
+		if tag == "-" {
+			continue
+		}
+
+		col := &column{goName: field.Name(), name: field.Name(), typ: field.Type()}
+		for p, ok := col.typ.(*types.Pointer); ok; p, ok = col.typ.(*types.Pointer) {
+			col.typ = p.Elem()
+		}
+
+		for _, t := range tags.Parse(tag) {
+			if t.Name != "name" {
+				continue
+			}
+
+			if len(t.Args) != 1 {
+				return fmt.Errorf("%s 的 name 属性发生以下错误: 过多的参数值", field.Name())
+			}
+			col.name = t.Args[0]
+		}
+
+		if slices.ContainsFunc(m.columns, func(c *column) bool { return c.name == col.name || c.goName == col.goName }) {
+			return fmt.Errorf("存在相同的列名 %s", col.name)
+		}
+		m.columns = append(m.columns, col)
+	}
+
+	return nil
+}
+
+// 生成 m 对应的代码，p 为代码所在的包。
+func (m *model) render(p *types.Package) ([]byte, error) {
+	imports := map[string]string{exprPath: "expr"} // path: name
+	qualifier := func(other *types.Package) string {
+		if other == p {
+			return ""
+		}
+
+		if name, found := imports[other.Path()]; found {
+			return name
+		}
+
+		name := other.Name()
+		for i := 1; slices.Contains(slices.Collect(maps.Values(imports)), name); i++ {
+			name = other.Name() + strconv.Itoa(i)
+		}
+		imports[other.Path()] = name
+		return name
+	}
+
+	body := &bytes.Buffer{}
+	fmt.Fprintf(body, "// %s 各个字段对应的列名\n", m.name)
+	body.WriteString("const (\n")
+	for _, c := range m.columns {
+		fmt.Fprintf(body, "%s = %s\n", m.constName(c), strconv.Quote(c.name))
+	}
+	body.WriteString(")\n\n")
+
+	fmt.Fprintf(body, "// %s %s 各个字段对应的列\n", m.ident("Columns"), m.name)
+	fmt.Fprintf(body, "var %s = struct {\n", m.ident("Columns"))
+	for _, c := range m.columns {
+		fmt.Fprintf(body, "%s expr.Col[%s]\n", c.goName, types.TypeString(c.typ, qualifier))
+	}
+	body.WriteString("}{\n")
+	for _, c := range m.columns {
+		fmt.Fprintf(body, "%s: expr.Column[%s](%s),\n", c.goName, types.TypeString(c.typ, qualifier), m.constName(c))
+	}
+	body.WriteString("}\n\n")
+
+	recv := m.name + "{}"
+	if m.ptrRecv {
+		recv = "(&" + recv + ")"
+	}
+	fmt.Fprintf(body, "// %s 返回 %s 的表名\n//\n// 返回值包含了表名前缀的占位符 #，可直接用于 sqlbuilder。\n", m.ident("Table"), m.name)
+	fmt.Fprintf(body, "func %s() string { return \"#\" + %s.TableName() }\n", m.ident("Table"), recv)
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("// Code generated by ormgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "package %s\n\n", p.Name())
+	buf.WriteString("import (\n")
+	std := func(path string) bool { return !strings.Contains(strings.Split(path, "/")[0], ".") }
+	paths := slices.SortedFunc(maps.Keys(imports), func(a, b string) int { // 标准库排在前面
+		if sa, sb := std(a), std(b); sa != sb {
+			if sa {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	for i, path := range paths {
+		if i > 0 && std(paths[i-1]) != std(path) { // 标准库与第三方包之间以空行分隔
+			buf.WriteByte('\n')
+		}
+
+		if name := imports[path]; name != lastElem(path) {
+			fmt.Fprintf(buf, "%s %s\n", name, strconv.Quote(path))
+		} else {
+			fmt.Fprintf(buf, "%s\n", strconv.Quote(path))
+		}
+	}
+	buf.WriteString(")\n\n")
+	buf.Write(body.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+// 生成以模型名称开头的标识符
+func (m *model) ident(suffix string) string { return m.name + suffix }
+
+func (m *model) constName(c *column) string { return m.ident("Column" + c.goName) }
+
+// 包导入路径的最后一段，对于 /vN 形式的路径，返回上一段。
+func lastElem(path string) string {
+	elems := strings.Split(path, "/")
+	last := elems[len(elems)-1]
+	if len(elems) > 1 && len(last) > 1 && last[0] == 'v' {
+		if _, err := strconv.Atoi(last[1:]); err == nil {
+			return elems[len(elems)-2]
+		}
+	}
+	return last
+}
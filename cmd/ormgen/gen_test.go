@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+)
+
+func TestGenerate(t *testing.T) {
+	a := assert.New(t, false)
+
+	pkgs, err := loadPackages("./testdata/models")
+	a.NotError(err).Length(pkgs, 1)
+	p := pkgs[0]
+
+	files, err := generate(p)
+	a.NotError(err).Length(files, 2)
+
+	astFiles := append([]*ast.File{}, p.files...)
+	for name, data := range files {
+		golden, err := os.ReadFile(filepath.Join("testdata", name+".golden"))
+		a.NotError(err).Equal(string(data), string(golden), "%s", name)
+
+		f, err := parser.ParseFile(p.fset, name, data, 0)
+		a.NotError(err)
+		astFiles = append(astFiles, f)
+	}
+
+	// 生成的代码可以正常编译
+	conf := &types.Config{Importer: p.imp}
+	_, err = conf.Check(p.path, p.fset, astFiles, nil)
+	a.NotError(err)
+}
+
+func TestParseModels_dupColumn(t *testing.T) {
+	a := assert.New(t, false)
+
+	st := types.NewStruct([]*types.Var{
+		types.NewField(0, nil, "F1", types.Typ[types.Int], false),
+		types.NewField(0, nil, "F2", types.Typ[types.Int], false),
+	}, []string{`orm:"name(id)"`, `orm:"name(id)"`})
+	m := &model{name: "M"}
+	a.ErrorString(m.parseColumns(st), "存在相同的列名")
+
+	st = types.NewStruct([]*types.Var{
+		types.NewField(0, nil, "F1", types.Typ[types.Int], false),
+	}, []string{`orm:"name(id,1)"`})
+	m = &model{name: "M"}
+	a.ErrorString(m.parseColumns(st), "过多的参数值")
+}
+
+func TestLastElem(t *testing.T) {
+	a := assert.New(t, false)
+	a.Equal(lastElem("time"), "time").
+		Equal(lastElem("github.com/issue9/orm/v6"), "orm").
+		Equal(lastElem("github.com/issue9/orm/v6/core"), "core").
+		Equal(lastElem("example.com/v2x"), "v2x")
+}
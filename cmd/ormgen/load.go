@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// 生成文件的后缀
+const fileSuffix = "_ormgen.go"
+
+type pkg struct {
+	dir   string
+	path  string
+	fset  *token.FileSet
+	files []*ast.File
+	types *types.Package
+	imp   types.Importer
+}
+
+// go list -json 输出内容中用到的字段
+type listPackage struct {
+	Dir        string
+	ImportPath string
+	GoFiles    []string
+	Error      *struct{ Err string }
+}
+
+// 加载 patterns 指定的包
+//
+// 已经生成的文件不会被加载，由此产生的类型错误也会被忽略。
+func loadPackages(patterns ...string) ([]*pkg, error) {
+	args := append([]string{"list", "-e", "-json"}, patterns...)
+	cmd := exec.Command("go", args...)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.New(strings.TrimSpace(stderr.String()))
+	}
+
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil).(types.ImporterFrom)
+
+	pkgs := make([]*pkg, 0, len(patterns))
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		lp := &listPackage{}
+		if err := dec.Decode(lp); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if lp.Error != nil {
+			return nil, errors.New(lp.Error.Err)
+		}
+
+		p, err := loadPackage(fset, imp, lp)
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, p)
+	}
+
+	return pkgs, nil
+}
+
+func loadPackage(fset *token.FileSet, imp types.ImporterFrom, lp *listPackage) (*pkg, error) {
+	files := make([]*ast.File, 0, len(lp.GoFiles))
+	for _, name := range lp.GoFiles {
+		if strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, filepath.Join(lp.Dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	di := &dirImporter{dir: lp.Dir, imp: imp}
+	conf := &types.Config{
+		Importer: di,
+		Error:    func(error) {}, // 忽略错误，可能是引用了未生成的代码。
+	}
+	tp, _ := conf.Check(lp.ImportPath, fset, files, nil)
+
+	return &pkg{
+		dir:   lp.Dir,
+		path:  lp.ImportPath,
+		fset:  fset,
+		files: files,
+		types: tp,
+		imp:   di,
+	}, nil
+}
+
+// 以包所在目录为基准导入其它包
+type dirImporter struct {
+	dir string
+	imp types.ImporterFrom
+}
+
+func (i *dirImporter) Import(path string) (*types.Package, error) {
+	return i.imp.ImportFrom(path, i.dir, 0)
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+// ormgen 根据数据模型生成列的描述代码
+//
+// 查找包中所有实现了 [core.TableNamer] 接口的结构体，
+// 按照 orm 标签的规则分析其列名，并为每一个模型生成一个 *_ormgen.go 文件，
+// 包含以下内容：
+//   - 列名常量，比如 UserColumnName；
+//   - 带类型的列，比如 UserColumns.Name，类型为 expr.Col[string]；
+//   - 返回表名的函数，比如 UserTable()；
+//
+// 之后构建语句时使用生成的代码，当字段被修改时，会在编译期就报错，而不是运行时。
+//
+// 用法：
+//
+//	ormgen [packages]
+//
+// packages 为需要处理的包，格式与 go list 相同，默认为当前目录。
+// 一般可以在模型所在的包中添加以下指令：
+//
+//	//go:generate go run github.com/issue9/orm/v6/cmd/ormgen
+//
+// [core.TableNamer]: https://pkg.go.dev/github.com/issue9/orm/v6/core#TableNamer
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "用法：ormgen [packages]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	if err := run(patterns); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(patterns []string) error {
+	pkgs, err := loadPackages(patterns...)
+	if err != nil {
+		return err
+	}
+
+	for _, pkg := range pkgs {
+		files, err := generate(pkg)
+		if err != nil {
+			return err
+		}
+
+		for name, data := range files {
+			if err := os.WriteFile(filepath.Join(pkg.dir, name), data, 0o644); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Base struct {
+	ID      int64     `orm:"name(id);ai"`
+	Created time.Time `orm:"name(created)"`
+}
+
+type User struct {
+	Base
+	Name     string         `orm:"name(name);len(20)"`
+	Age      *int           `orm:"name(age);nullable"`
+	Nickname sql.NullString `orm:"name(nickname)"`
+	Version  int64          `orm:"name(version);occ"`
+	Ignore   int            `orm:"-"`
+	password string
+}
+
+type Group struct {
+	ID   int64 `orm:"name(id);ai"`
+	Name string
+}
+
+// 未实现 TableName
+type NotModel struct {
+	ID int64
+}
+
+func (*User) TableName() string { return "users" }
+
+func (Group) TableName() string { return "groups" }
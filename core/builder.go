@@ -60,12 +60,22 @@ func (b *Builder) Quote(str string, l, r byte) *Builder { return b.WBytes(l).WSt
 // QuoteKey 给 str 左右添加 [QuoteLeft] 和 [QuoteRight] 两个字符
 func (b *Builder) QuoteKey(str string) *Builder { return b.Quote(str, QuoteLeft, QuoteRight) }
 
+// QuoteTable 为表名添加 [QuoteLeft] 和 [QuoteRight] 两个字符
+//
+// NOTE: 表名可能包含模式名：schema.table，此时会分别为两者添加引号。
+func (b *Builder) QuoteTable(table string) *Builder {
+	if index := strings.IndexByte(table, '.'); index > 0 {
+		return b.QuoteKey(table[:index]).WBytes('.').QuoteKey(table[index+1:])
+	}
+	return b.QuoteKey(table)
+}
+
 // QuoteColumn 为列名添加 [QuoteLeft] 和 [QuoteRight] 两个字符
 //
-// NOTE: 列名可能包含表名或是表名别名：table.col
+// NOTE: 列名可能包含表名或是表名别名：table.col，表名也可以包含模式名：schema.table.col
 func (b *Builder) QuoteColumn(col string) *Builder {
-	if index := strings.IndexByte(col, '.'); index > 0 {
-		return b.QuoteKey(col[:index]).WBytes('.').QuoteKey(col[index+1:])
+	if index := strings.LastIndexByte(col, '.'); index > 0 {
+		return b.QuoteTable(col[:index]).WBytes('.').QuoteKey(col[index+1:])
 	}
 	return b.Quote(col, QuoteLeft, QuoteRight)
 }
@@ -43,6 +43,24 @@ func TestSQLBuilder(t *testing.T) {
 	bs, err := b.Bytes()
 	a.NotError(err).Equal(bs, []byte("{key}"))
 
+	b.Reset()
+	b.QuoteKey("schema.#tbl")
+	str, err = b.String()
+	a.NotError(err).Equal(str, "{schema.#tbl}")
+
+	b.Reset()
+	b.QuoteTable("schema.#tbl")
+	str, err = b.String()
+	a.NotError(err).Equal(str, "{schema}.{#tbl}")
+
+	b.Reset()
+	b.QuoteColumn("schema.tbl.col")
+	str, err = b.String()
+	a.NotError(err).Equal(str, "{schema}.{tbl}.{col}")
+
+	b.Reset()
+	b.QuoteKey("key")
+
 	buf := NewBuilder("buf-")
 	buf.Append(b)
 	bs, err = buf.Bytes()
@@ -5,8 +5,10 @@
 package core
 
 import (
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"slices"
 )
 
 var errInvalidColumnType = errors.New("无效的列类型")
@@ -30,11 +32,68 @@ type Column struct {
 
 	PrimitiveType PrimitiveType
 	GoName        string // Go 中的字段名
+
+	// Generator 插入数据时为零值的列生成值
+	//
+	// 与 AI 由数据库生成不同，该值由客户端在插入之前生成并写回对象，比如 uuid(v7) 指定的主键。
+	Generator func() (any, error)
+
+	// Enums 列的可选值
+	//
+	// 由 [Enumer] 接口获得，值只能是字符串或是整数，为空表示不限定取值范围。
+	Enums []any
+}
+
+// Enumer 限定了取值范围的类型
+//
+// 一般用于以一组常量表示状态的自定义类型，底层类型只能是字符串或是整数，
+// Enums 返回的应该是与该类型相同的常量列表，且每次返回的内容都应该相同。
+//
+// 创建表时会根据数据库生成原生的枚举类型或是 CHECK 约束，
+// 从数据库读取时也会拒绝不在该列表中的值。
+type Enumer interface {
+	Enums() []any
+}
+
+// EnumValue 将枚举值 v 转换成 [driver.Value]
+//
+// 转换后的值只能是 string 或是 int64，否则返回错误。
+func EnumValue(v any) (driver.Value, error) {
+	val, err := driver.DefaultParameterConverter.ConvertValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	switch val.(type) {
+	case string, int64:
+		return val, nil
+	default:
+		return nil, fmt.Errorf("无效的枚举值 %v", v)
+	}
+}
+
+// CheckEnum 检测 v 的值是否在 v.Enums() 之中
+func CheckEnum(v Enumer) error {
+	val, err := EnumValue(v)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range v.Enums() {
+		ev, err := EnumValue(e)
+		if err != nil {
+			return err
+		}
+		if ev == val {
+			return nil
+		}
+	}
+	return fmt.Errorf("%v 不是有效的枚举值", val)
 }
 
 // NewColumn 从 Go 类型中生成 [Column]
 func NewColumn(p PrimitiveType) (*Column, error) {
-	if p <= Auto || p >= maxPrimitiveType {
+	if _, found := p.Custom(); !found && (p <= Auto || p >= maxPrimitiveType) {
 		return nil, ErrInvalidColumnType()
 	}
 
@@ -60,7 +119,20 @@ func (c *Column) Check() error {
 		return fmt.Errorf("AutoIncrement 列 %s 不能同时带 NULL 约束", c.Name)
 	}
 
-	if c.PrimitiveType == String || c.PrimitiveType == Bytes {
+	if c.AI && c.Generator != nil {
+		return fmt.Errorf("AutoIncrement 列 %s 不能同时指定生成函数", c.Name)
+	}
+
+	pt := c.PrimitiveType
+	if ct, found := pt.Custom(); found {
+		pt = ct.Fallback
+	}
+
+	if err := c.checkEnums(pt); err != nil {
+		return err
+	}
+
+	if pt == String || pt == Bytes {
 		if len(c.Length) > 0 && (c.Length[0] < -1 || c.Length[0] == 0) {
 			return fmt.Errorf("列 %s 的长度只能是 -1 或是 >0", c.Name)
 		}
@@ -75,6 +147,44 @@ func (c *Column) Check() error {
 	return nil
 }
 
+func (c *Column) checkEnums(pt PrimitiveType) error {
+	if c.Enums == nil {
+		return nil
+	}
+
+	if len(c.Enums) == 0 {
+		return fmt.Errorf("列 %s 的枚举值不能为空", c.Name)
+	}
+
+	if c.AI {
+		return fmt.Errorf("AutoIncrement 列 %s 不能是枚举类型", c.Name)
+	}
+
+	isString := pt == String
+	if !isString && (pt < Int || pt > Uint64) {
+		return fmt.Errorf("枚举列 %s 只能是字符串或是整数类型", c.Name)
+	}
+
+	vals := make([]driver.Value, 0, len(c.Enums))
+	for _, e := range c.Enums {
+		v, err := EnumValue(e)
+		if err != nil {
+			return fmt.Errorf("列 %s：%w", c.Name, err)
+		}
+
+		if _, ok := v.(string); ok != isString {
+			return fmt.Errorf("列 %s 的枚举值 %v 与列类型不匹配", c.Name, e)
+		}
+
+		if slices.Contains(vals, v) {
+			return fmt.Errorf("列 %s 存在重复的枚举值 %v", c.Name, e)
+		}
+		vals = append(vals, v)
+	}
+
+	return nil
+}
+
 // AddColumns 添加新列
 func (m *Model) AddColumns(col ...*Column) error {
 	for _, c := range col {
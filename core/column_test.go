@@ -96,4 +96,60 @@ func TestColumn_Check(t *testing.T) {
 	col.HasDefault = false
 	col.Nullable = true
 	a.Error(col.Check())
+
+	col.Nullable = false
+	col.Generator = func() (any, error) { return 1, nil }
+	a.Error(col.Check())
+}
+
+type enumStatus string
+
+func (s enumStatus) Enums() []any { return []any{enumStatus("draft"), enumStatus("published")} }
+
+func TestColumn_Check_enums(t *testing.T) {
+	a := assert.New(t, false)
+
+	col, err := NewColumn(String)
+	a.NotError(err).NotNil(col)
+	col.Enums = enumStatus("").Enums()
+	a.NotError(col.Check())
+
+	col.Enums = []any{}
+	a.Error(col.Check())
+
+	col.Enums = []any{"a", "a"}
+	a.Error(col.Check())
+
+	col.Enums = []any{"a", 1}
+	a.Error(col.Check())
+
+	col, err = NewColumn(Int8)
+	a.NotError(err).NotNil(col)
+	col.Enums = []any{1, uint8(2), int64(3)}
+	a.NotError(col.Check())
+
+	col.Enums = []any{1, "2"}
+	a.Error(col.Check())
+
+	col, err = NewColumn(Float64)
+	a.NotError(err).NotNil(col)
+	col.Enums = []any{1.5}
+	a.Error(col.Check())
+}
+
+func TestCheckEnum(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.NotError(CheckEnum(enumStatus("draft"))).
+		Error(CheckEnum(enumStatus("deleted"))).
+		Error(CheckEnum(enumStatus("")))
+
+	v, err := EnumValue(enumStatus("draft"))
+	a.NotError(err).Equal(v, "draft")
+
+	v, err = EnumValue(uint16(5))
+	a.NotError(err).Equal(v, int64(5))
+
+	v, err = EnumValue(1.5)
+	a.Error(err).Nil(v)
 }
@@ -8,9 +8,13 @@ package core
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 )
 
+// ErrOffline 离线模式下执行语句时返回的错误
+var ErrOffline = errors.New("离线模式无法执行语句")
+
 // 索引的类型
 const (
 	IndexDefault IndexType = iota // 普通的索引
@@ -154,6 +158,14 @@ type Dialect interface {
 	// NOTE: query 中不能同时存在 ? 和命名参数。因为如果是命名参数，则 Exec 等的参数顺序可以是随意的。
 	Prepare(sql string) (query string, orders map[string]int, err error)
 
+	// Literal 将 v 转换成 SQL 语句中的字面量
+	//
+	// 用于将参数直接嵌入到 SQL 语句中，比如 sqlbuilder 中的 CombineSQL 方法。
+	// 实现者需要根据数据库的规则对字符串进行转义，并处理 NULL、布尔值、
+	// 二进制内容以及时间等类型，无法处理的类型应该返回错误。
+	// v 如果实现了 [database/sql/driver.Valuer]，应该以其返回值为准。
+	Literal(v any) (string, error)
+
 	// Backup 备份数据库
 	//
 	// dsn 初始化数据库的参数，主要从其中获取数据库名称等参数；
@@ -161,5 +173,53 @@ type Dialect interface {
 	Backup(dsn, dest string) error
 }
 
+// Introspector 从数据库中读取表结构
+//
+// 由 [Dialect] 的实现者根据数据库的支持情况实现，
+// 读取的结果可用于比较模型与数据库的差异、生成文档和代码等。
+type Introspector interface {
+	// Tables 返回当前数据库中所有的表名
+	//
+	// 不包含视图和数据库自身的系统表。
+	Tables(Engine) ([]string, error)
+
+	// Introspect 从数据库中读取表 table 的结构
+	//
+	// 返回对象的 [Model.Name] 即为 table，各约束名与数据库中的保持一致。
+	// [Column.Default] 为数据库中默认值的字面量，比如 'abc' 返回的是 abc，
+	// 像 CURRENT_TIMESTAMP 等表达式形式的默认值会被忽略。
+	Introspect(e Engine, table string) (*Model, error)
+}
+
+// AdvisoryLocker 数据库提供的咨询锁
+//
+// 由 [Dialect] 的实现者根据数据库的支持情况实现，
+// 可用于在多个实例之间同步一些只能执行一次的操作，比如数据库迁移。
+//
+// NOTE: 咨询锁与数据库连接相关，加锁和解锁必须在同一个连接中执行。
+type AdvisoryLocker interface {
+	// LockSQL 获取名为 name 的锁的语句
+	//
+	// 如果该锁已经被其它连接占用，执行时会一直等待直到获得该锁。
+	LockSQL(name string) (string, []any)
+
+	// UnlockSQL 释放名为 name 的锁的语句
+	UnlockSQL(name string) (string, []any)
+}
+
+// ScriptFormatter 自定义语句在 SQL 脚本中的格式
+//
+// 由 [Dialect] 的实现者根据数据库客户端的要求实现，
+// 未实现此接口的数据库，脚本中的语句都以分号结尾，事务以 BEGIN; 开始，以 COMMIT; 结束。
+type ScriptFormatter interface {
+	// ScriptStatement 返回语句 query 在脚本中的形式，包含语句的结束符
+	ScriptStatement(query string) string
+
+	// ScriptTransaction 返回脚本中开始和提交事务的语句
+	//
+	// 返回值同样需要包含语句的结束符，为空表示不需要该语句。
+	ScriptTransaction() (begin, commit string)
+}
+
 // ErrConstraintExists 返回约束名已经存在的错误
 func ErrConstraintExists(c string) error { return fmt.Errorf("约束 %s 已经存在", c) }
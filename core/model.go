@@ -65,6 +65,11 @@ type (
 		// 模型的名称
 		Name string
 
+		// 模型所在的模式
+		//
+		// 为空表示采用数据库的默认模式，目前仅 postgres 和 cockroach 支持。
+		Schema string
+
 		// 视图的 select 语句
 		//
 		// 仅在 ModelType == View 时有效果，可由 [Engine.Query] 等处理。
@@ -132,6 +137,7 @@ func NewModel(modelType ModelType, name string, cap int) *Model {
 func (m *Model) Reset() {
 	m.GoType = nil
 	m.Name = ""
+	m.Schema = ""
 	m.ViewAs = ""
 	m.Type = none
 	m.Columns = m.Columns[:0]
@@ -387,6 +393,16 @@ func (m *Model) checkNames() error {
 	return nil
 }
 
+// FullName 包含模式的模型名称
+//
+// 格式为 schema.name，未指定 [Model.Schema] 时与 [Model.Name] 相同。
+func (m *Model) FullName() string {
+	if m.Schema == "" {
+		return m.Name
+	}
+	return m.Schema + "." + m.Name
+}
+
 func (m *Model) Index(name string) (*Constraint, bool) {
 	return sliceutil.At(m.Indexes, func(e *Constraint, _ int) bool { return e.Name == name })
 }
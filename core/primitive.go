@@ -6,6 +6,8 @@ package core
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"fmt"
 	"reflect"
 	"time"
 )
@@ -110,8 +112,25 @@ var (
 	}
 
 	primitiveTyperType = reflect.TypeFor[PrimitiveTyper]()
+
+	// 由 [RegisterPrimitiveType] 注册的类型
+	customTypes = map[PrimitiveType]*CustomType{}
 )
 
+// DialectValuer 由数据库决定写入的值
+//
+// 同一类型在不同数据库中的保存方式可能并不相同，比如 UUID 在 postgres 中为原生类型，
+// 而在 mysql 中则以二进制保存。实现此接口的参数会在 [Dialect.Fix] 和 [Dialect.Literal]
+// 中替换为 DialectValue 的返回值，未经过这两个方法的参数则依然采用 [driver.Valuer] 的返回值。
+type DialectValuer interface {
+	driver.Valuer
+
+	// DialectValue 返回在 dialect 数据库中实际保存的值
+	//
+	// dialect 为 [Dialect.Name] 的返回值。
+	DialectValue(dialect string) (driver.Value, error)
+}
+
 type PrimitiveTyper interface {
 	// NOTE: 最简单的方法是复用 [driver.Valuer] 接口，从其返回值中获取类型信息，
 	// 但是该接口有可能返回 nil 值，无法确定类型。
@@ -127,26 +146,110 @@ type PrimitiveTyper interface {
 // PrimitiveType 由 [Dialect.SQLType] 转换成相应数据的实际类型。
 type PrimitiveType int
 
+// CustomType 自定义的 [PrimitiveType]
+//
+// 用于表示像 postgres 的 uuid、jsonb、inet 等无法由内置类型表示的列类型。
+type CustomType struct {
+	// Name 类型名称
+	//
+	// 同时也是 [PrimitiveType.String] 的返回值，不能与其它类型重名。
+	Name string
+
+	// SQLTypes 各个数据库中对应的类型
+	//
+	// 键名为 [Dialect.Name]，键值为数据库中的类型名称，不需要包含 NOT NULL 和 DEFAULT 等内容，比如：
+	//
+	//	map[string]string{"postgres": "UUID", "cockroach": "UUID"}
+	SQLTypes map[string]string
+
+	// Fallback 当前数据库不在 [CustomType.SQLTypes] 中时，以此类型代替
+	//
+	// 只能是内置的类型，为 [Auto] 时表示 [String]。
+	Fallback PrimitiveType
+
+	// Default 将默认值转换成 SQL 语句中的字面量
+	//
+	// dialect 为 [Dialect.Name]，v 为列的默认值。
+	// 为空表示由 [Dialect] 自行处理。
+	Default func(dialect string, v any) (string, error)
+}
+
+// RegisterPrimitiveType 注册自定义的 [PrimitiveType]
+//
+// goTypes 为与该类型关联的 Go 类型，[GetPrimitiveType] 会直接返回这些类型对应的值，
+// 也可以由 [PrimitiveTyper] 接口返回该值：
+//
+//	var inet = core.RegisterPrimitiveType(&core.CustomType{Name: "inet", SQLTypes: map[string]string{"postgres": "INET"}})
+//
+//	func (INet) PrimitiveType() core.PrimitiveType { return inet }
+//
+// NOTE: 该函数非并发安全，应该在包的初始化阶段调用，参数错误时会直接 panic。
+func RegisterPrimitiveType(t *CustomType, goTypes ...reflect.Type) PrimitiveType {
+	if t.Name == "" {
+		panic("参数 t.Name 不能为空")
+	}
+
+	if t.Fallback == Auto {
+		t.Fallback = String
+	} else if t.Fallback < Auto || t.Fallback >= maxPrimitiveType {
+		panic(fmt.Sprintf("无效的 Fallback 值 %d", t.Fallback))
+	}
+
+	for _, name := range typeStrings {
+		if name == t.Name {
+			panic(fmt.Sprintf("类型 %s 已经存在", t.Name))
+		}
+	}
+	for _, c := range customTypes {
+		if c.Name == t.Name {
+			panic(fmt.Sprintf("类型 %s 已经存在", t.Name))
+		}
+	}
+
+	for _, typ := range goTypes {
+		if _, found := types[typ]; found {
+			panic(fmt.Sprintf("Go 类型 %s 已经关联了其它类型", typ))
+		}
+	}
+
+	p := maxPrimitiveType + 1 + PrimitiveType(len(customTypes))
+	customTypes[p] = t
+	for _, typ := range goTypes {
+		types[typ] = p
+	}
+	return p
+}
+
 // GetPrimitiveType 获取 t 所关联的 [PrimitiveType] 值
 //
+// 依次从 [RegisterPrimitiveType] 注册的 Go 类型、[PrimitiveTyper] 接口以及 t.Kind 中查找。
 // t.Kind 不能为 [reflect.Ptr] 否则将返回 [Auto]。
 func GetPrimitiveType(t reflect.Type) PrimitiveType {
-	primitiveType, found := kinds[t.Kind()]
-	if found {
+	if primitiveType, found := types[t]; found {
 		return primitiveType
 	}
 
-	primitiveType, found = types[t]
-	if !found {
+	if k := t.Kind(); k != reflect.Ptr && k != reflect.Interface {
 		v := reflect.New(t).Elem()
 		if t.Implements(primitiveTyperType) {
-			primitiveType = v.Interface().(PrimitiveTyper).PrimitiveType()
+			return v.Interface().(PrimitiveTyper).PrimitiveType()
 		} else if v.Addr().Type().Implements(primitiveTyperType) {
-			primitiveType = v.Addr().Interface().(PrimitiveTyper).PrimitiveType()
+			return v.Addr().Interface().(PrimitiveTyper).PrimitiveType()
 		}
 	}
 
-	return primitiveType
+	return kinds[t.Kind()]
+}
+
+// Custom 返回由 [RegisterPrimitiveType] 注册的类型信息
+func (t PrimitiveType) Custom() (*CustomType, bool) {
+	ct, found := customTypes[t]
+	return ct, found
 }
 
-func (t PrimitiveType) String() string { return typeStrings[t] }
+func (t PrimitiveType) String() string {
+	if ct, found := customTypes[t]; found {
+		return ct.Name
+	}
+	return typeStrings[t]
+}
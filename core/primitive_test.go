@@ -46,3 +46,59 @@ func TestGetPrimitiveType(t *testing.T) {
 	field, _ := reflect.ValueOf(o2).Type().FieldByName("Any")
 	a.Equal(GetPrimitiveType(field.Type), String)
 }
+
+type inet string
+
+func TestRegisterPrimitiveType(t *testing.T) {
+	a := assert.New(t, false)
+
+	ct := &CustomType{Name: "inet", SQLTypes: map[string]string{"postgres": "INET"}}
+	p := RegisterPrimitiveType(ct, reflect.TypeFor[inet]())
+	a.True(p > maxPrimitiveType).
+		Equal(p.String(), "inet").
+		Equal(ct.Fallback, String).
+		Equal(GetPrimitiveType(reflect.TypeFor[inet]()), p)
+
+	c, found := p.Custom()
+	a.True(found).Equal(c, ct)
+	_, found = String.Custom()
+	a.False(found)
+
+	col, err := NewColumn(p)
+	a.NotError(err).Equal(col.PrimitiveType, p)
+	col.Length = []int{-1}
+	a.NotError(col.Check())
+
+	// 重名
+	a.PanicString(func() {
+		RegisterPrimitiveType(&CustomType{Name: "inet"})
+	}, "类型 inet 已经存在")
+	a.PanicString(func() {
+		RegisterPrimitiveType(&CustomType{Name: "string"})
+	}, "类型 string 已经存在")
+
+	// Go 类型已经关联
+	a.Panic(func() {
+		RegisterPrimitiveType(&CustomType{Name: "inet2"}, reflect.TypeFor[inet]())
+	})
+
+	a.Panic(func() {
+		RegisterPrimitiveType(&CustomType{Name: "inet3", Fallback: p})
+	})
+
+	a.Panic(func() {
+		RegisterPrimitiveType(&CustomType{})
+	})
+}
+
+type typerInt int
+
+func (typerInt) PrimitiveType() PrimitiveType { return String }
+
+func TestGetPrimitiveType_typer(t *testing.T) {
+	a := assert.New(t, false)
+
+	// PrimitiveTyper 优先于 Kind
+	a.Equal(GetPrimitiveType(reflect.TypeFor[typerInt]()), String)
+	a.Equal(GetPrimitiveType(reflect.TypeFor[*typerInt]()), Auto)
+}
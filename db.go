@@ -20,6 +20,7 @@ type DB struct {
 	sqlBuilder  *sqlbuilder.SQLBuilder
 	models      *model.Models
 	dsn         string
+	script      *scriptEngine // 非空表示处于 DryRun 模式
 }
 
 // NewDB 声明一个新的 [DB] 实例
@@ -63,15 +64,35 @@ func (db *DB) New(tablePrefix string) *DB {
 	}
 
 	e := db.models.NewEngine(db.DB(), tablePrefix)
-	return &DB{
+	n := &DB{
 		Engine:      e,
 		tablePrefix: tablePrefix,
 		sqlBuilder:  sqlbuilder.New(e),
 		models:      db.models,
 		dsn:         db.dsn,
 	}
+
+	if db.script != nil {
+		return n.DryRun()
+	}
+	return n
 }
 
+// SetSchema 指定模型的默认模式
+//
+// 未在 [ApplyModeler] 中指定模式的模型都将采用此值，
+// 目前仅 postgres 和 cockroach 支持，其它数据库指定非空值时将返回错误。
+// 同时会清除已经缓存的模型，影响范围包括由 [DB.New] 派生的对象。
+//
+// NOTE: 如果只需要改变未限定模式的对象的查找路径，也可以直接在 dsn 中指定 search_path。
+func (db *DB) SetSchema(schema string) error { return db.models.SetSchema(schema) }
+
+// Schema 模型的默认模式
+func (db *DB) Schema() string { return db.models.Schema() }
+
+// DryRun 模式下不会启用事务
+func (db *DB) transactionalDDL() bool { return db.script == nil && db.Dialect().TransactionalDDL() }
+
 // Close 关闭连接
 //
 // 同时会清除缓存的模型数据。
@@ -116,6 +137,21 @@ func (db *DB) DeleteContext(ctx context.Context, v TableNamer) (sql.Result, erro
 	return del(ctx, db, v)
 }
 
+// DeleteMany 一次删除多条数据
+//
+// 会自动转换成事务进行处理。
+func (db *DB) DeleteMany(v ...TableNamer) (int64, error) {
+	return db.DeleteManyContext(context.Background(), v...)
+}
+
+func (db *DB) DeleteManyContext(ctx context.Context, v ...TableNamer) (affected int64, err error) {
+	err = db.DoTransactionTx(ctx, nil, func(tx *Tx) error {
+		affected, err = tx.DeleteManyContext(ctx, v...)
+		return err
+	})
+	return affected, err
+}
+
 func (db *DB) Update(v TableNamer, cols ...string) (sql.Result, error) {
 	return db.UpdateContext(context.Background(), v, cols...)
 }
@@ -131,7 +167,7 @@ func (db *DB) SelectContext(ctx context.Context, v TableNamer) (bool, error) { r
 func (db *DB) Create(v ...TableNamer) error { return db.CreateContext(context.Background(), v...) }
 
 func (db *DB) CreateContext(ctx context.Context, v ...TableNamer) error {
-	if !db.Dialect().TransactionalDDL() {
+	if !db.transactionalDDL() {
 		for _, t := range v {
 			if err := create(ctx, db, t); err != nil {
 				return err
@@ -153,7 +189,7 @@ func (db *DB) CreateContext(ctx context.Context, v ...TableNamer) error {
 func (db *DB) Drop(v ...TableNamer) error { return db.DropContext(context.Background(), v...) }
 
 func (db *DB) DropContext(ctx context.Context, v ...TableNamer) error {
-	if !db.Dialect().TransactionalDDL() {
+	if !db.transactionalDDL() {
 		for _, t := range v {
 			if err := drop(ctx, db, t); err != nil {
 				return err
@@ -177,7 +213,7 @@ func (db *DB) Truncate(v ...TableNamer) error {
 }
 
 func (db *DB) TruncateContext(ctx context.Context, v ...TableNamer) error {
-	if !db.Dialect().TransactionalDDL() {
+	if !db.transactionalDDL() {
 		for _, t := range v {
 			if err := truncate(ctx, db, t); err != nil {
 				return err
@@ -14,6 +14,7 @@ import (
 
 	"github.com/issue9/orm/v6"
 	"github.com/issue9/orm/v6/internal/test"
+	"github.com/issue9/orm/v6/types"
 )
 
 func TestMain(m *testing.M) {
@@ -65,6 +66,48 @@ func TestDB_InsertDefaultValues(t *testing.T) {
 	})
 }
 
+type uuidKey struct {
+	ID   types.UUID      `orm:"name(id);pk;uuid(v7)"`
+	Ref  *types.TextUUID `orm:"name(ref);nullable;uuid(v4)"`
+	Name string          `orm:"name(name);len(20)"`
+}
+
+func (v *uuidKey) TableName() string { return "uuid_keys" }
+
+func TestDB_InsertUUID(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(d *test.Driver) {
+		d.NotError(d.DB.Create(&uuidKey{}))
+		defer func() {
+			d.NotError(d.DB.Drop(&uuidKey{}))
+		}()
+
+		k1 := &uuidKey{Name: "k1"}
+		_, err := d.DB.Insert(k1)
+		d.NotError(err).False(k1.ID.IsZero()).NotNil(k1.Ref)
+
+		// 已经有值的不会再生成
+		id, err := types.NewUUIDv4()
+		d.NotError(err)
+		k2 := &uuidKey{ID: id, Name: "k2"}
+		_, err = d.DB.Insert(k2)
+		d.NotError(err).Equal(k2.ID, id)
+
+		k3, k4 := &uuidKey{Name: "k3"}, &uuidKey{Name: "k4"}
+		d.NotError(d.DB.InsertMany(10, k3, k4))
+		d.False(k3.ID.IsZero()).False(k4.ID.IsZero()).NotEqual(k3.ID, k4.ID)
+		hasCount(d.DB, a, "uuid_keys", 4)
+
+		f := &uuidKey{ID: k1.ID}
+		found, err := d.DB.Select(f)
+		d.NotError(err).True(found).
+			Equal(f.Name, "k1").
+			Equal(f.Ref, k1.Ref)
+	})
+}
+
 func TestDB_Update(t *testing.T) {
 	a := assert.New(t, false)
 	suite := test.NewSuite(a, "")
@@ -233,6 +276,54 @@ func TestDB_Delete(t *testing.T) {
 	})
 }
 
+func TestDB_DeleteMany(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initData(t)
+		defer clearData(t)
+
+		t.NotError(t.DB.InsertMany(10,
+			&UserInfo{UID: 3, FirstName: "f3", LastName: "l3"},
+			&UserInfo{UID: 4, FirstName: "f4", LastName: "l4"},
+			&UserInfo{UID: 5, FirstName: "f5", LastName: "l5"},
+		))
+		hasCount(t.DB, t.Assertion, "user_info", 5)
+
+		// 主键与唯一约束混合
+		cnt, err := t.DB.DeleteMany(
+			&UserInfo{UID: 1},
+			&UserInfo{LastName: "l2", FirstName: "f2"},
+			&UserInfo{UID: 3},
+			&UserInfo{UID: 100}, // 不存在
+		)
+		t.NotError(err).Equal(cnt, 3)
+		hasCount(t.DB, t.Assertion, "user_info", 2)
+
+		// 多列唯一约束
+		cnt, err = t.DB.DeleteMany(
+			&UserInfo{LastName: "l4", FirstName: "f4"},
+			&UserInfo{LastName: "l5", FirstName: "f5"},
+		)
+		t.NotError(err).Equal(cnt, 2)
+		hasCount(t.DB, t.Assertion, "user_info", 0)
+
+		// 不同的类型
+		cnt, err = t.DB.DeleteMany(&UserInfo{UID: 1}, &Admin{Email: "email1"})
+		t.Error(err).Zero(cnt)
+		hasCount(t.DB, t.Assertion, "administrators", 1)
+
+		// 没有可用的查询条件
+		cnt, err = t.DB.DeleteMany(&Admin{Email: "email1"}, &Admin{})
+		t.Error(err).Zero(cnt)
+		hasCount(t.DB, t.Assertion, "administrators", 1)
+
+		cnt, err = t.DB.DeleteMany()
+		t.NotError(err).Zero(cnt)
+	})
+}
+
 func TestDB_Truncate(t *testing.T) {
 	a := assert.New(t, false)
 	suite := test.NewSuite(a, "")
@@ -12,8 +12,15 @@ import (
 )
 
 var (
-	quoteApostrophe  = strings.NewReplacer("'", "''")  // 标准 SQL 用法
-	escapeApostrophe = strings.NewReplacer("'", "\\'") // mysql 用法
+	quoteApostrophe  = strings.NewReplacer("'", "''") // 标准 SQL 用法
+	escapeApostrophe = strings.NewReplacer(           // mysql 用法
+		"\\", "\\\\",
+		"'", "\\'",
+		"\x00", "\\0",
+		"\n", "\\n",
+		"\r", "\\r",
+		"\x1a", "\\Z",
+	)
 )
 
 type base struct {
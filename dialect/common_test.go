@@ -7,12 +7,14 @@ package dialect_test
 import (
 	"database/sql"
 	"math"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/issue9/assert/v4"
 
 	"github.com/issue9/orm/v6/core"
+	"github.com/issue9/orm/v6/internal/model"
 	"github.com/issue9/orm/v6/internal/sqltest"
 	"github.com/issue9/orm/v6/internal/test"
 	"github.com/issue9/orm/v6/sqlbuilder"
@@ -23,6 +25,55 @@ func TestMain(m *testing.M) {
 	test.Main(m)
 }
 
+// 不需要连接数据库，仅用于生成 SQL 的测试对象，表名前缀为 p_。
+type offlineDB struct {
+	core.Engine
+	ms       *model.Models
+	replacer *strings.Replacer
+}
+
+func newOfflineDB(d core.Dialect) *offlineDB {
+	ms, e := model.NewOfflineModels(d, "p_")
+	l, r := d.Quotes()
+	return &offlineDB{
+		Engine:   e,
+		ms:       ms,
+		replacer: strings.NewReplacer(string(core.QuoteLeft), string(l), string(core.QuoteRight), string(r), "#", "p_"),
+	}
+}
+
+func (db *offlineDB) SQLBuilder() *sqlbuilder.SQLBuilder { return sqlbuilder.New(db) }
+
+func (db *offlineDB) Close() error { return db.ms.Close() }
+
+func (db *offlineDB) Render(stmt sqlbuilder.SQLer) (string, []any, error) {
+	query, args, err := stmt.SQL()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if query, args, err = db.Dialect().Fix(query, args); err != nil {
+		return "", nil, err
+	}
+	return db.replacer.Replace(query), args, nil
+}
+
+func (db *offlineDB) RenderDDL(stmt sqlbuilder.DDLSQLer) ([]string, error) {
+	qs, err := stmt.DDLSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]string, 0, len(qs))
+	for _, q := range qs {
+		if q, _, err = db.Dialect().Fix(q, nil); err != nil {
+			return nil, err
+		}
+		ret = append(ret, db.replacer.Replace(q))
+	}
+	return ret, nil
+}
+
 type sqlTypeTester struct {
 	col     *core.Column
 	err     bool
@@ -7,8 +7,14 @@ package dialect
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 	"unicode"
 
 	"github.com/issue9/orm/v6/core"
@@ -18,6 +24,8 @@ import (
 var (
 	errColIsNil = errors.New("参数 col 参数是个空值")
 
+	jsonPathKey = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
 	datetimeLayouts = []string{
 		"2006-01-02 15:04:05",
 		"2006-01-02 15:04:05.9",
@@ -37,10 +45,137 @@ func invalidTimeFractional(col *core.Column) error {
 	return fmt.Errorf("列 %s 时间精度只能介于 [0,6] 之间", col.Name)
 }
 
+// 由 [core.RegisterPrimitiveType] 注册的类型
+//
+// 如果 name 数据库存在对应的类型，返回该类型名称，
+// 否则返回空值以及用于代替的 [core.PrimitiveType]。
+func customSQLType(name string, col *core.Column) (string, core.PrimitiveType) {
+	ct, found := col.PrimitiveType.Custom()
+	if !found {
+		return "", col.PrimitiveType
+	}
+
+	if typ, found := ct.SQLTypes[name]; found {
+		return typ, col.PrimitiveType
+	}
+	return "", ct.Fallback
+}
+
+// 由 [core.CustomType.Default] 格式化默认值
+//
+// found 表示是否由自定义类型处理了该值，值为 nil 时始终由 [core.Dialect] 处理。
+func customDefault(name string, col *core.Column) (v string, found bool, err error) {
+	ct, found := col.PrimitiveType.Custom()
+	if !found || ct.Default == nil || col.Default == nil {
+		return "", false, nil
+	}
+
+	v, err = ct.Default(name, col.Default)
+	return v, true, err
+}
+
+// path 中可以转换成非负整数的元素表示数组下标
+func isJSONIndex(p string) bool {
+	_, err := strconv.ParseUint(p, 10, 0)
+	return err == nil
+}
+
+// 将 path 转换成 mysql 和 sqlite3 使用的 JSON 路径，比如 $."a"[0]
+func jsonPath(path []string) string {
+	b := strings.Builder{}
+	b.WriteByte('$')
+	for _, p := range path {
+		if isJSONIndex(p) {
+			b.WriteByte('[')
+			b.WriteString(p)
+			b.WriteByte(']')
+			continue
+		}
+
+		b.WriteString(`."`)
+		b.WriteString(jsonPathKey.Replace(p))
+		b.WriteByte('"')
+	}
+	return b.String()
+}
+
 func errUncovert(col *core.Column) error {
 	return fmt.Errorf("不支持的列类型: %s", col.Name)
 }
 
+// 将 v 转换成 SQL 字面量
+//
+// v 会先经过 [driver.DefaultParameterConverter] 转换，
+// 之后交由 f 处理与数据库相关的类型，f 返回 false 表示未处理该类型，
+// 此时由 literal 处理 NULL 和数值等通用的类型。
+func literal(name string, v any, f func(driver.Value) (string, bool)) (string, error) {
+	dv, err := dialectValue(name, v)
+	if err != nil {
+		return "", err
+	}
+
+	val, err := driver.DefaultParameterConverter.ConvertValue(dv)
+	if err != nil {
+		return "", err
+	}
+
+	if s, ok := f(val); ok {
+		return s, nil
+	}
+
+	switch vv := val.(type) {
+	case nil:
+		return "NULL", nil
+	case int64:
+		return strconv.FormatInt(vv, 10), nil
+	case float64:
+		return strconv.FormatFloat(vv, 'g', -1, 64), nil
+	}
+	return "", fmt.Errorf("无法将 %T 转换成 SQL 字面量", v)
+}
+
+// 如果 v 实现了 [core.DialectValuer]，返回其在 name 数据库中的值
+func dialectValue(name string, v any) (any, error) {
+	if named, ok := v.(sql.NamedArg); ok {
+		val, err := dialectValue(name, named.Value)
+		if err != nil {
+			return nil, err
+		}
+		named.Value = val
+		return named, nil
+	}
+
+	dv, ok := v.(core.DialectValuer)
+	if !ok {
+		return v, nil
+	}
+
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil, nil
+	}
+	return dv.DialectValue(name)
+}
+
+// 将 args 中实现了 [core.DialectValuer] 的参数替换为其在 name 数据库中的值
+func fixArgs(name string, args []any) error {
+	for index, arg := range args {
+		v, err := dialectValue(name, arg)
+		if err != nil {
+			return err
+		}
+		args[index] = v
+	}
+	return nil
+}
+
+// 时间字面量的格式，统一转换为 UTC。
+func timeLiteral(t time.Time) string {
+	return "'" + t.In(time.UTC).Format(datetimeLayouts[len(datetimeLayouts)-1]) + "'"
+}
+
+// 十六进制表示的二进制字面量，mysql 和 sqlite3 均支持此格式。
+func hexLiteral(b []byte) string { return "X'" + hex.EncodeToString(b) + "'" }
+
 // mysqlLimitSQL mysql 系列数据库分页语法的实现
 //
 // 支持以下数据库：MySQL, H2, HSQLDB, Postgres, SQLite3
@@ -99,6 +234,7 @@ func oracleLimitSQL(limit any, offset ...any) (string, []any) {
 //
 // 命名参数替换成 ?，并返回参数名称对应在语句的位置。
 // query 中不能同时包含命名参数和 ?，否则将 panic。
+// @ 之后如果不是参数名称，比如 postgres 的 @> 操作符，则原样输出。
 func PrepareNamedArgs(query string) (string, map[string]int, error) {
 	orders := map[string]int{}
 	builder := core.NewBuilder("")
@@ -118,6 +254,9 @@ func PrepareNamedArgs(query string) (string, map[string]int, error) {
 		switch {
 		case c == '@':
 			start = index + 1
+		case start == index && !(unicode.IsLetter(c) || unicode.IsDigit(c)): // 非命名参数
+			builder.WRunes('@', c)
+			start = -1
 		case start != -1 && !(unicode.IsLetter(c) || unicode.IsDigit(c)):
 			write(query[start:index])
 			builder.WRunes(c) // 当前的字符不能丢
@@ -131,7 +270,10 @@ func PrepareNamedArgs(query string) (string, map[string]int, error) {
 		}
 	}
 
-	if start > -1 {
+	switch {
+	case start == len(query):
+		builder.WBytes('@')
+	case start > -1:
 		write(query[start:])
 	}
 
@@ -151,7 +293,7 @@ func stdDropIndex(index string) (string, error) {
 }
 
 func appendViewBody(builder *core.Builder, name, selectQuery string, cols []string) (string, error) {
-	builder.WString(" VIEW ").QuoteKey(name)
+	builder.WString(" VIEW ").QuoteTable(name)
 
 	if len(cols) > 0 {
 		builder.WBytes('(')
@@ -165,7 +307,9 @@ func appendViewBody(builder *core.Builder, name, selectQuery string, cols []stri
 }
 
 // 修正查询语句和查询参数的位置
-func fixQueryAndArgs(query string, args []any) (string, []any, error) {
+//
+// 同时会将参数转换成在 name 数据库中实际保存的值。
+func fixQueryAndArgs(name, query string, args []any) (string, []any, error) {
 	query, orders, err := PrepareNamedArgs(query)
 	if err != nil {
 		return "", nil, err
@@ -193,5 +337,148 @@ func fixQueryAndArgs(query string, args []any) (string, []any, error) {
 		args[index] = val
 	}
 
+	if err := fixArgs(name, args); err != nil {
+		return "", nil, err
+	}
 	return query, args, nil
 }
+
+// 版本号 ver 是否不低于 min
+//
+// ver 为 3.45.1 之类以点号分隔的版本号，无法解析的部分当作 0 处理。
+func versionAtLeast(ver string, min ...int) bool {
+	parts := strings.Split(strings.TrimSpace(ver), ".")
+	for i, m := range min {
+		var n int
+		if i < len(parts) {
+			n, _ = strconv.Atoi(strings.TrimFunc(parts[i], func(r rune) bool { return !unicode.IsDigit(r) }))
+		}
+
+		if n != m {
+			return n > m
+		}
+	}
+	return true
+}
+
+func queryStrings(e core.Engine, query string, args ...any) (ret []string, err error) {
+	rows, err := e.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errors.Join(err, rows.Close()) }()
+
+	for rows.Next() {
+		var s string
+		if err = rows.Scan(&s); err != nil {
+			return nil, err
+		}
+		ret = append(ret, s)
+	}
+	return ret, rows.Err()
+}
+
+// 将 VARCHAR(20) 之类的类型拆分成类型名称和长度
+//
+// 返回的类型名称为大写。
+func splitType(typ string) (string, []int) {
+	typ = strings.ToUpper(strings.TrimSpace(typ))
+
+	start := strings.IndexByte(typ, '(')
+	if start < 0 {
+		return typ, nil
+	}
+	end := strings.IndexByte(typ[start:], ')')
+	if end < 0 {
+		return typ, nil
+	}
+	end += start
+
+	var l []int
+	for _, v := range strings.Split(typ[start+1:end], ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return typ, nil
+		}
+		l = append(l, n)
+	}
+	return strings.TrimSpace(typ[:start] + typ[end+1:]), l
+}
+
+// 分析数据库中的默认值
+//
+// 只有字面量才会被当作默认值，像 CURRENT_TIMESTAMP 等表达式会被忽略。
+func parseDefault(v string) (any, bool) {
+	v = trimParens(v)
+
+	if len(v) > 1 && v[0] == '\'' { // 'abc' 或是 postgres 的 'abc'::character varying
+		end := 1
+		for ; end < len(v); end++ {
+			if v[end] != '\'' {
+				continue
+			}
+			if end+1 < len(v) && v[end+1] == '\'' { // 转义的 ''
+				end++
+				continue
+			}
+			break
+		}
+
+		if end >= len(v) || (end < len(v)-1 && !strings.HasPrefix(v[end+1:], "::")) {
+			return nil, false
+		}
+		return strings.ReplaceAll(v[1:end], "''", "'"), true
+	}
+
+	if i := strings.Index(v, "::"); i > 0 {
+		v = v[:i]
+	}
+
+	switch lower := strings.ToLower(v); lower {
+	case "", "null":
+		return nil, false
+	case "true", "false":
+		return lower, true
+	}
+
+	if _, err := strconv.ParseFloat(v, 64); err == nil {
+		return v, true
+	}
+	return nil, false
+}
+
+// 去掉包含整个表达式的括号
+//
+// (a>0) 返回 a>0，而 (a>0) AND (b>0) 则原样返回。
+func trimParens(v string) string {
+	v = strings.TrimSpace(v)
+
+LOOP:
+	for len(v) > 1 && v[0] == '(' && v[len(v)-1] == ')' {
+		deep := 0
+		for i, c := range v {
+			switch c {
+			case '(':
+				deep++
+			case ')':
+				deep--
+				if deep == 0 && i < len(v)-1 { // 第一个括号并未包含整个表达式
+					break LOOP
+				}
+			}
+		}
+		v = strings.TrimSpace(v[1 : len(v)-1])
+	}
+
+	return v
+}
+
+// 外键的更新和删除规则，数据库的默认值统一返回空值。
+func fkRule(rule string) string {
+	switch rule = strings.ToUpper(strings.TrimSpace(rule)); rule {
+	case "NO ACTION", "RESTRICT":
+		return ""
+	default:
+		return rule
+	}
+}
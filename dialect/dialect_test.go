@@ -10,6 +10,7 @@ import (
 
 	"github.com/issue9/assert/v4"
 
+	"github.com/issue9/orm/v6/core"
 	"github.com/issue9/orm/v6/internal/sqltest"
 )
 
@@ -96,6 +97,16 @@ func TestPrepareNamedArgs(t *testing.T) {
 			query:  "select * from table where id=? and id=1 and id=?",
 			orders: map[string]int{"id": 0, "id2": 1},
 		},
+		{ // postgres 的 @> 操作符
+			input:  "select * from table where {data} @> ? and {data} <@ ?",
+			query:  "select * from table where {data} @> ? and {data} <@ ?",
+			orders: map[string]int{},
+		},
+		{
+			input:  "select * from table where {data} @> @data",
+			query:  "select * from table where {data} @> ?",
+			orders: map[string]int{"data": 0},
+		},
 	}
 
 	for _, item := range data {
@@ -145,25 +156,144 @@ func TestFixQueryAndArgs(t *testing.T) {
 	}
 
 	for _, item := range data {
-		query, args, err := fixQueryAndArgs(item.query, item.args)
+		query, args, err := fixQueryAndArgs("mysql", item.query, item.args)
 		a.NotError(err).
 			Equal(args, item.outputArgs)
 		sqltest.Equal(a, query, item.outputQuery)
 	}
 
 	a.Panic(func() {
-		fixQueryAndArgs("select * from table where id=@id  and id=@id2", []any{sql.Named("id2", 1), sql.Named("id3", 2)})
+		fixQueryAndArgs("mysql", "select * from table where id=@id  and id=@id2", []any{sql.Named("id2", 1), sql.Named("id3", 2)})
 	})
 
 	a.Panic(func() {
-		fixQueryAndArgs("select * from table where id=@id and  id=@id2", []any{sql.Named("id2", 1), sql.Named("not-exists", 2)})
+		fixQueryAndArgs("mysql", "select * from table where id=@id and  id=@id2", []any{sql.Named("id2", 1), sql.Named("not-exists", 2)})
 	})
 
 	a.Panic(func() {
-		fixQueryAndArgs("select * from table where id=@id and id=@id", []any{sql.Named("id", 1), sql.Named("id2", 2)})
+		fixQueryAndArgs("mysql", "select * from table where id=@id and id=@id", []any{sql.Named("id", 1), sql.Named("id2", 2)})
 	})
 
 	a.Panic(func() {
-		fixQueryAndArgs("select * from table where id=@id  and id=?", []any{sql.Named("id", 1)})
+		fixQueryAndArgs("mysql", "select * from table where id=@id  and id=?", []any{sql.Named("id", 1)})
 	})
 }
+
+func TestVersionAtLeast(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.True(versionAtLeast("3.33.0", 3, 33)).
+		True(versionAtLeast("3.45.1", 3, 33)).
+		True(versionAtLeast("4.0", 3, 33)).
+		True(versionAtLeast(" 3.33 ", 3, 33)).
+		True(versionAtLeast("8.0.33", 8)).
+		False(versionAtLeast("5.7.44-log", 8)).
+		False(versionAtLeast("3.32.3", 3, 33)).
+		False(versionAtLeast("3.9.2", 3, 33)).
+		False(versionAtLeast("2.99", 3, 33)).
+		False(versionAtLeast("", 3, 33))
+}
+
+func TestParseDefault(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		input string
+		v     any
+		ok    bool
+	}{
+		{input: "5", v: "5", ok: true},
+		{input: "-1.5", v: "-1.5", ok: true},
+		{input: "'abc'", v: "abc", ok: true},
+		{input: "'it''s'", v: "it's", ok: true},
+		{input: "('abc')", v: "abc", ok: true},
+		{input: "'abc'::character varying", v: "abc", ok: true},
+		{input: "0::bigint", v: "0", ok: true},
+		{input: "TRUE", v: "true", ok: true},
+		{input: "NULL"},
+		{input: "CURRENT_TIMESTAMP"},
+		{input: "now()"},
+		{input: "'a' || 'b'"},
+	}
+
+	for _, item := range data {
+		v, ok := parseDefault(item.input)
+		a.Equal(ok, item.ok, item.input).Equal(v, item.v, item.input)
+	}
+}
+
+func TestSplitType(t *testing.T) {
+	a := assert.New(t, false)
+
+	typ, l := splitType("varchar(20)")
+	a.Equal(typ, "VARCHAR").Equal(l, []int{20})
+
+	typ, l = splitType("DECIMAL(10, 2) unsigned")
+	a.Equal(typ, "DECIMAL UNSIGNED").Equal(l, []int{10, 2})
+
+	typ, l = splitType("text")
+	a.Equal(typ, "TEXT").Nil(l)
+
+	p, l := sqlite3Type("VARCHAR(20)")
+	a.Equal(p, core.String).Equal(l, []int{20})
+	p, l = sqlite3Type("INTEGER")
+	a.Equal(p, core.Int64).Nil(l)
+	p, _ = sqlite3Type("")
+	a.Equal(p, core.Bytes)
+	p, l = sqlite3Type("NUMERIC(5,2)")
+	a.Equal(p, core.Decimal).Equal(l, []int{5, 2})
+}
+
+func TestTrimParens(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(trimParens("(a>0)"), "a>0").
+		Equal(trimParens("((a>0))"), "a>0").
+		Equal(trimParens(" ( a>0 ) "), "a>0").
+		Equal(trimParens("(a>0) AND (b>0)"), "(a>0) AND (b>0)").
+		Equal(trimParens("a>0"), "a>0").
+		Equal(trimParens("()"), "")
+}
+
+func TestFKRule(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(fkRule("NO ACTION"), "").
+		Equal(fkRule("restrict"), "").
+		Equal(fkRule("cascade"), "CASCADE").
+		Equal(fkRule(" SET NULL "), "SET NULL")
+}
+
+var inetType = core.RegisterPrimitiveType(&core.CustomType{
+	Name:     "dialect_inet",
+	SQLTypes: map[string]string{"postgres": "INET"},
+	Default: func(dialect string, v any) (string, error) {
+		if dialect == "postgres" {
+			return "'" + v.(string) + "'::inet", nil
+		}
+		return "'" + v.(string) + "'", nil
+	},
+})
+
+func TestCustomType(t *testing.T) {
+	a := assert.New(t, false)
+
+	col := &core.Column{PrimitiveType: inetType, HasDefault: true, Default: "127.0.0.1"}
+	typ, err := Postgres("postgres").SQLType(col)
+	a.NotError(err).Equal(typ, "INET NOT NULL DEFAULT '127.0.0.1'::inet")
+
+	// 采用 Fallback 代替
+	typ, err = Mysql("mysql").SQLType(&core.Column{PrimitiveType: inetType, Length: []int{50}, HasDefault: true, Default: "127.0.0.1"})
+	a.NotError(err).Equal(typ, "VARCHAR(50) NOT NULL DEFAULT '127.0.0.1'")
+
+	typ, err = Sqlite3("sqlite3").SQLType(col)
+	a.NotError(err).Equal(typ, "TEXT NOT NULL DEFAULT '127.0.0.1'")
+
+	// nil 由 Dialect 处理
+	typ, err = Postgres("postgres").SQLType(&core.Column{PrimitiveType: inetType, Nullable: true, HasDefault: true})
+	a.NotError(err).Equal(typ, "INET DEFAULT NULL")
+
+	// 未注册的类型
+	_, err = Postgres("postgres").SQLType(&core.Column{PrimitiveType: inetType + 100})
+	a.Error(err)
+}
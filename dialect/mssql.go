@@ -0,0 +1,346 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package dialect
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/issue9/orm/v6/core"
+	"github.com/issue9/orm/v6/sqlbuilder"
+)
+
+type mssql struct {
+	base
+}
+
+var (
+	_ sqlbuilder.CreateTableIfNotExistsHooker = &mssql{}
+	_ sqlbuilder.AlterColumnStmtHooker        = &mssql{}
+	_ sqlbuilder.RenameColumnStmtHooker       = &mssql{}
+	_ sqlbuilder.RenameTableStmtHooker        = &mssql{}
+	_ sqlbuilder.LimitOrderHooker             = &mssql{}
+	_ core.ScriptFormatter                    = &mssql{}
+)
+
+// Mssql 返回一个适配 SQL Server 的 [core.Dialect] 接口
+//
+// 最低要求 SQL Server 2016，参数采用 @p1、@p2 的形式，
+// 适用于 github.com/microsoft/go-mssqldb 等驱动。
+//
+// NOTE: SQL Server 的 OFFSET ... FETCH 必须与 ORDER BY 一起使用，
+// 未指定排序方式时，会以 ORDER BY (SELECT NULL) 代替，此时返回数据的顺序是不确定的。
+func Mssql(driverName string) core.Dialect {
+	return &mssql{
+		base: newBase("mssql", driverName, '[', ']'),
+	}
+}
+
+func (m *mssql) VersionSQL() string {
+	return `SELECT CAST(SERVERPROPERTY('ProductVersion') AS NVARCHAR(128))`
+}
+
+func (m *mssql) Fix(query string, args []any) (string, []any, error) {
+	query, args, err := fixQueryAndArgs(m.Name(), query, args)
+	if err != nil {
+		return "", nil, err
+	}
+	return m.replace(query), args, nil
+}
+
+func (m *mssql) Prepare(query string) (string, map[string]int, error) {
+	query, orders, err := PrepareNamedArgs(query)
+	if err != nil {
+		return "", nil, err
+	}
+	return m.replace(query), orders, nil
+}
+
+// 将 ? 替换成 @p1、@p2 的形式
+func (m *mssql) replace(query string) string {
+	if strings.IndexByte(query, '?') < 0 {
+		return query
+	}
+
+	num := 1
+	build := strings.Builder{}
+	for _, c := range query {
+		if c == '?' {
+			build.WriteString("@p")
+			build.WriteString(strconv.Itoa(num))
+			num++
+			continue
+		}
+		build.WriteRune(c)
+	}
+	return build.String()
+}
+
+// Literal 字符串以 N'abc' 的形式表示，以支持 Unicode 字符。
+func (m *mssql) Literal(v any) (string, error) {
+	return literal(m.Name(), v, func(v driver.Value) (string, bool) {
+		switch vv := v.(type) {
+		case bool:
+			if vv {
+				return "1", true
+			}
+			return "0", true
+		case string:
+			return "N'" + quoteApostrophe.Replace(vv) + "'", true
+		case []byte:
+			return "0x" + hex.EncodeToString(vv), true
+		case time.Time:
+			return timeLiteral(vv), true
+		}
+		return "", false
+	})
+}
+
+// LastInsertIDSQL 在插入语句之后通过 SCOPE_IDENTITY() 获取当前作用域中的自增值
+func (m *mssql) LastInsertIDSQL(_, _ string) (sql string, append bool) {
+	return "; SELECT CAST(SCOPE_IDENTITY() AS BIGINT)", true
+}
+
+func (m *mssql) CreateTableOptionsSQL(*core.Builder, map[string][]string) error { return nil }
+
+// LimitSQL 采用 OFFSET ... FETCH 语法
+//
+// SQL Server 不支持单独的 FETCH，未指定 offset 时，以 0 代替。
+func (m *mssql) LimitSQL(limit any, offset ...any) (string, []any) {
+	if len(offset) == 0 {
+		offset = []any{0}
+	}
+	query, args := oracleLimitSQL(limit, offset...)
+	return " " + query, args
+}
+
+func (m *mssql) LimitOrderHook() string { return " ORDER BY (SELECT NULL)" }
+
+func (m *mssql) CreateTableIfNotExistsHook(table, query string) (string, error) {
+	return "IF OBJECT_ID(N'" + quoteApostrophe.Replace(table) + "', N'U') IS NULL " + query, nil
+}
+
+// AlterColumnStmtHook 通过 ALTER COLUMN 修改列的类型和是否可为空
+//
+// SQL Server 的默认值是一个独立的约束，无法通过 ALTER COLUMN 修改。
+func (m *mssql) AlterColumnStmtHook(stmt *sqlbuilder.AlterColumnStmt) ([]string, error) {
+	if stmt.Col.HasDefault {
+		return nil, errors.New("mssql 无法通过 ALTER COLUMN 修改默认值")
+	}
+
+	col := stmt.Col.Clone()
+	col.AI = false // IDENTITY 只能用于创建列
+	typ, err := m.SQLType(col)
+	if err != nil {
+		return nil, err
+	}
+	if col.Nullable {
+		typ += " NULL"
+	}
+
+	query, err := core.NewBuilder("ALTER TABLE ").
+		QuoteKey(stmt.TableName).
+		WString(" ALTER COLUMN ").
+		QuoteKey(stmt.Col.Name).
+		WBytes(' ').
+		WString(typ).
+		String()
+	if err != nil {
+		return nil, err
+	}
+	return []string{query}, nil
+}
+
+// RenameColumnStmtHook 通过 sp_rename 重命名列
+func (m *mssql) RenameColumnStmtHook(stmt *sqlbuilder.RenameColumnStmt) ([]string, error) {
+	query, err := core.NewBuilder("EXEC sp_rename N'").
+		QuoteKey(stmt.TableName).
+		WBytes('.').
+		QuoteKey(stmt.OldName).
+		WString("', N'").
+		WString(quoteApostrophe.Replace(stmt.Name)).
+		WString("', N'COLUMN'").
+		String()
+	if err != nil {
+		return nil, err
+	}
+	return []string{query}, nil
+}
+
+// RenameTableStmtHook 通过 sp_rename 重命名表
+func (m *mssql) RenameTableStmtHook(stmt *sqlbuilder.RenameTableStmt) ([]string, error) {
+	query, err := core.NewBuilder("EXEC sp_rename N'").
+		QuoteKey(stmt.OldName).
+		WString("', N'").
+		WString(quoteApostrophe.Replace(stmt.Name)).
+		WBytes('\'').
+		String()
+	if err != nil {
+		return nil, err
+	}
+	return []string{query}, nil
+}
+
+// TruncateTableSQL SQL Server 的 TRUNCATE TABLE 会自动重置 IDENTITY 列
+func (m *mssql) TruncateTableSQL(table, _ string) ([]string, error) {
+	query, err := core.NewBuilder("TRUNCATE TABLE ").QuoteKey(table).String()
+	if err != nil {
+		return nil, err
+	}
+	return []string{query}, nil
+}
+
+func (m *mssql) CreateViewSQL(replace, temporary bool, name, selectQuery string, cols []string) ([]string, error) {
+	if temporary {
+		return nil, errors.New("mssql 不支持临时视图")
+	}
+
+	builder := core.NewBuilder("CREATE ")
+	if replace {
+		builder.WString("OR ALTER")
+	}
+
+	q, err := appendViewBody(builder, name, selectQuery, cols)
+	if err != nil {
+		return nil, err
+	}
+	return []string{q}, nil
+}
+
+func (m *mssql) TransactionalDDL() bool { return true }
+
+func (m *mssql) ScriptStatement(query string) string { return query + ";" }
+
+// ScriptTransaction SQL Server 不支持单独的 BEGIN 作为事务的开始
+func (m *mssql) ScriptTransaction() (begin, commit string) {
+	return "BEGIN TRANSACTION;", "COMMIT TRANSACTION;"
+}
+
+func (m *mssql) DropIndexSQL(table, index string) (string, error) {
+	if table == "" {
+		return "", sqlbuilder.SyntaxError("DROP", "未指定表名")
+	}
+	if index == "" {
+		return "", sqlbuilder.SyntaxError("DROP", "未指定列")
+	}
+
+	return core.NewBuilder("DROP INDEX ").
+		QuoteKey(index).
+		WString(" ON ").
+		QuoteKey(table).
+		String()
+}
+
+func (m *mssql) ExistsSQL(name string, view bool) (string, []any) {
+	t := "BASE TABLE"
+	if view {
+		t = "VIEW"
+	}
+	return "SELECT TABLE_NAME AS name FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_TYPE=? AND TABLE_NAME=?", []any{t, name}
+}
+
+// Backup SQL Server 的备份文件只能由服务端生成，请直接使用 BACKUP DATABASE 语句。
+func (m *mssql) Backup(string, string) error {
+	return errors.New("mssql 不支持 Backup 操作")
+}
+
+func (m *mssql) SQLType(col *core.Column) (string, error) {
+	if col == nil {
+		return "", errColIsNil
+	}
+
+	typ, primitiveType := customSQLType(m.Name(), col)
+	if typ != "" {
+		return m.buildType(typ, col, 0)
+	}
+
+	switch primitiveType {
+	case core.Bool:
+		return m.buildType("BIT", col, 0)
+	case core.Uint8: // TINYINT 为无符号类型
+		return m.buildType("TINYINT", col, 0)
+	case core.Int8, core.Int16:
+		return m.buildType("SMALLINT", col, 0)
+	case core.Uint16, core.Int32:
+		return m.buildType("INT", col, 0)
+	case core.Uint32, core.Int64, core.Int, core.Uint64, core.Uint:
+		return m.buildType("BIGINT", col, 0)
+	case core.Float32:
+		return m.buildType("REAL", col, 0)
+	case core.Float64:
+		return m.buildType("FLOAT", col, 0)
+	case core.Decimal:
+		if len(col.Length) != 2 {
+			return "", missLength(col)
+		}
+		return m.buildType("DECIMAL", col, 2)
+	case core.String:
+		if len(col.Length) == 0 || col.Length[0] == -1 || col.Length[0] > 4000 {
+			return m.buildType("NVARCHAR(MAX)", col, 0)
+		}
+		return m.buildType("NVARCHAR", col, 1)
+	case core.Bytes:
+		return m.buildType("VARBINARY(MAX)", col, 0)
+	case core.Time:
+		if len(col.Length) == 0 {
+			return m.buildType("DATETIME2", col, 0)
+		}
+		if col.Length[0] < 0 || col.Length[0] > 6 {
+			return "", invalidTimeFractional(col)
+		}
+		return m.buildType("DATETIME2", col, 1)
+	default:
+		return "", errUncovert(col)
+	}
+}
+
+// l 表示需要取的长度数量
+func (m *mssql) buildType(typ string, col *core.Column, l int) (string, error) {
+	w := core.NewBuilder(typ)
+
+	switch {
+	case l == 1 && len(col.Length) > 0:
+		w.Quote(strconv.Itoa(col.Length[0]), '(', ')')
+	case l == 2 && len(col.Length) > 1:
+		w.WBytes('(').
+			WString(strconv.Itoa(col.Length[0])).
+			WBytes(',').
+			WString(strconv.Itoa(col.Length[1])).
+			WBytes(')')
+	}
+
+	if col.AI {
+		w.WString(" IDENTITY(1,1)")
+	}
+
+	if !col.Nullable {
+		w.WString(" NOT NULL")
+	}
+
+	if col.HasDefault {
+		v, err := m.formatSQL(col)
+		if err != nil {
+			return "", err
+		}
+		w.WString(" DEFAULT ").WString(v)
+	}
+
+	return w.String()
+}
+
+func (m *mssql) formatSQL(col *core.Column) (string, error) {
+	if v, found, err := customDefault(m.Name(), col); found {
+		return v, err
+	}
+
+	if t, ok := col.Default.(time.Time); ok {
+		return formatTime(col, t)
+	}
+	return m.Literal(col.Default)
+}
@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package dialect_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/issue9/assert/v4"
+
+	"github.com/issue9/orm/v6/core"
+	"github.com/issue9/orm/v6/dialect"
+	"github.com/issue9/orm/v6/internal/sqltest"
+)
+
+func TestMssql_Fix(t *testing.T) {
+	a := assert.New(t, false)
+	d := dialect.Mssql("sqlserver")
+
+	query, args, err := d.Fix("SELECT * FROM tbl WHERE id=? AND name=?", []any{1, "n"})
+	a.NotError(err).Equal(args, []any{1, "n"})
+	sqltest.Equal(a, query, "SELECT * FROM tbl WHERE id=@p1 AND name=@p2")
+
+	query, args, err = d.Fix("SELECT * FROM tbl WHERE id=@id AND name=@name", []any{sql.Named("name", "n"), sql.Named("id", 1)})
+	a.NotError(err).Equal(args, []any{1, "n"})
+	sqltest.Equal(a, query, "SELECT * FROM tbl WHERE id=@p1 AND name=@p2")
+
+	query, orders, err := d.Prepare("SELECT * FROM tbl WHERE id=@id AND name=@name")
+	a.NotError(err).Equal(orders, map[string]int{"id": 0, "name": 1})
+	sqltest.Equal(a, query, "SELECT * FROM tbl WHERE id=@p1 AND name=@p2")
+}
+
+func TestMssql_Literal(t *testing.T) {
+	a := assert.New(t, false)
+	d := dialect.Mssql("sqlserver")
+
+	data := []struct {
+		v   any
+		out string
+	}{
+		{v: nil, out: "NULL"},
+		{v: 5, out: "5"},
+		{v: true, out: "1"},
+		{v: `a'b\c`, out: `N'a''b\c'`},
+		{v: []byte{0x0a, 0xff}, out: "0x0aff"},
+		{v: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), out: "'2024-01-02 03:04:05'"},
+	}
+	for _, item := range data {
+		out, err := d.Literal(item.v)
+		a.NotError(err).Equal(out, item.out, "%v", item.v)
+	}
+}
+
+func TestMssql_SQLType(t *testing.T) {
+	a := assert.New(t, false)
+
+	var data = []*sqlTypeTester{
+		{
+			col: &core.Column{PrimitiveType: core.Auto},
+			err: true,
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Bool},
+			SQLType: "BIT NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Int8},
+			SQLType: "SMALLINT NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Uint8},
+			SQLType: "TINYINT NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Int16},
+			SQLType: "SMALLINT NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Uint16},
+			SQLType: "INT NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Int32},
+			SQLType: "INT NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Uint32},
+			SQLType: "BIGINT NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Int64, AI: true},
+			SQLType: "BIGINT IDENTITY(1,1) NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Uint, Nullable: true},
+			SQLType: "BIGINT",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Float32},
+			SQLType: "REAL NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Float64},
+			SQLType: "FLOAT NOT NULL",
+		},
+		{
+			col: &core.Column{PrimitiveType: core.Decimal},
+			err: true,
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Decimal, Length: []int{10, 2}},
+			SQLType: "DECIMAL(10,2) NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.String, Length: []int{100}, HasDefault: true, Default: "a'b"},
+			SQLType: "NVARCHAR(100) NOT NULL DEFAULT N'a''b'",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.String, Length: []int{-1}},
+			SQLType: "NVARCHAR(MAX) NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.String, Length: []int{5000}},
+			SQLType: "NVARCHAR(MAX) NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Bytes},
+			SQLType: "VARBINARY(MAX) NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Bool, HasDefault: true, Default: true},
+			SQLType: "BIT NOT NULL DEFAULT 1",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Time},
+			SQLType: "DATETIME2 NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Time, Length: []int{3}},
+			SQLType: "DATETIME2(3) NOT NULL",
+		},
+		{
+			col: &core.Column{PrimitiveType: core.Time, Length: []int{7}},
+			err: true,
+		},
+	}
+
+	testSQLType(a, dialect.Mssql("sqlserver"), data)
+}
+
+func TestMssql_sqlbuilder(t *testing.T) {
+	a := assert.New(t, false)
+	db := newOfflineDB(dialect.Mssql("sqlserver"))
+	defer func() { a.NotError(db.Close()) }()
+	sb := db.SQLBuilder()
+
+	qs, err := db.RenderDDL(sb.CreateTable().Table("#users").
+		AutoIncrement("id", core.Int64).
+		Column("name", core.String, false, false, false, nil, 20).
+		Index(core.IndexDefault, "index_name", "name"))
+	a.NotError(err).Length(qs, 2)
+	sqltest.Equal(a, qs[0], "IF OBJECT_ID(N'p_users', N'U') IS NULL CREATE TABLE [p_users]([id] BIGINT IDENTITY(1,1) NOT NULL,[name] NVARCHAR(20) NOT NULL)")
+	sqltest.Equal(a, qs[1], "CREATE INDEX index_name ON [p_users]([name])")
+
+	query, args, err := db.Render(sb.Select().Column("*").From("#users").Where("id>?", 5).Desc("id").Limit(10, 20))
+	a.NotError(err).Equal(args, []any{5, 20, 10})
+	sqltest.Equal(a, query, "SELECT * FROM [p_users] WHERE id>@p1 ORDER BY id DESC OFFSET @p2 ROWS FETCH NEXT @p3 ROWS ONLY")
+
+	query, args, err = db.Render(sb.Select().Column("*").From("#users").Asc("id").Limit(10))
+	a.NotError(err).Equal(args, []any{0, 10})
+	sqltest.Equal(a, query, "SELECT * FROM [p_users] ORDER BY id ASC OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY")
+
+	// 未指定 ORDER BY
+	query, args, err = db.Render(sb.Select().Column("*").From("#users").Limit(10))
+	a.NotError(err).Equal(args, []any{0, 10})
+	sqltest.Equal(a, query, "SELECT * FROM [p_users] ORDER BY (SELECT NULL) OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY")
+
+	query, args, err = db.Render(sb.Delete().Table("#users").Where("id>?", 5).Limit(10, "id"))
+	a.NotError(err).Equal(args, []any{5, 0, 10})
+	sqltest.Equal(a, query, "DELETE FROM [p_users] WHERE [id] IN (SELECT [id] FROM [p_users] WHERE id>@p1 ORDER BY (SELECT NULL) OFFSET @p2 ROWS FETCH NEXT @p3 ROWS ONLY)")
+
+	q, apd := db.Dialect().LastInsertIDSQL("#users", "id")
+	a.True(apd).Equal(q, "; SELECT CAST(SCOPE_IDENTITY() AS BIGINT)")
+
+	qs, err = db.RenderDDL(sb.DropConstraint().Table("#users").Constraint("users_pk"))
+	a.NotError(err)
+	sqltest.Equal(a, qs[0], "ALTER TABLE [p_users] DROP CONSTRAINT [users_pk]")
+
+	qs, err = db.RenderDDL(sb.DropIndex().Table("#users").Name("index_name"))
+	a.NotError(err)
+	sqltest.Equal(a, qs[0], "DROP INDEX [index_name] ON [p_users]")
+
+	qs, err = db.RenderDDL(sb.AlterColumn().Table("#users").Column("name", core.String, false, true, false, nil, 50))
+	a.NotError(err)
+	sqltest.Equal(a, qs[0], "ALTER TABLE [p_users] ALTER COLUMN [name] NVARCHAR(50) NULL")
+
+	qs, err = db.RenderDDL(sb.AlterColumn().Table("#users").Column("name", core.String, false, true, true, "", 50))
+	a.Error(err).Nil(qs)
+
+	qs, err = db.RenderDDL(sb.RenameColumn().Table("#users").Column("name", "it's"))
+	a.NotError(err).Equal(qs, []string{"EXEC sp_rename N'[p_users].[name]', N'it''s', N'COLUMN'"})
+
+	qs, err = db.RenderDDL(sb.RenameTable().Table("#users", "#members"))
+	a.NotError(err).Equal(qs, []string{"EXEC sp_rename N'[p_users]', N'p_members'"})
+
+	qs, err = db.RenderDDL(sb.TruncateTable().Table("#users", "id"))
+	a.NotError(err).Equal(qs, []string{"TRUNCATE TABLE [p_users]"})
+
+	qs, err = db.RenderDDL(sb.CreateView().Name("v").Replace().FromQuery("SELECT * FROM #users"))
+	a.NotError(err)
+	sqltest.Equal(a, qs[0], "CREATE OR ALTER VIEW [v] AS SELECT * FROM p_users")
+
+	a.Error(db.Dialect().Backup("", ""))
+
+	f, ok := db.Dialect().(core.ScriptFormatter)
+	a.True(ok).Equal(f.ScriptStatement("DROP TABLE [p_users]"), "DROP TABLE [p_users];")
+	begin, commit := f.ScriptTransaction()
+	a.Equal(begin, "BEGIN TRANSACTION;").Equal(commit, "COMMIT TRANSACTION;")
+}
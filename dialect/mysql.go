@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -34,7 +35,15 @@ type mysql struct {
 
 var (
 	_ sqlbuilder.DropConstraintStmtHooker = &mysql{}
+	_ sqlbuilder.AlterColumnStmtHooker    = &mysql{}
 	_ sqlbuilder.InsertDefaultValueHooker = &mysql{}
+	_ sqlbuilder.WithHooker               = &mysql{}
+	_ sqlbuilder.JoinSyntaxHooker         = &mysql{}
+	_ sqlbuilder.UpdateDeleteLimitHooker  = &mysql{}
+	_ sqlbuilder.JSONHooker               = &mysql{}
+	_ sqlbuilder.EnumHooker               = &mysql{}
+	_ core.Introspector                   = &mysql{}
+	_ core.AdvisoryLocker                 = &mysql{}
 )
 
 // Mysql 返回一个适配 mysql 的 [core.Dialect] 接口
@@ -59,15 +68,50 @@ func newMysql(isMariadb bool, name, driverName string) core.Dialect {
 }
 
 func (m *mysql) Fix(query string, args []any) (string, []any, error) {
-	return fixQueryAndArgs(query, args)
+	return fixQueryAndArgs(m.Name(), query, args)
 }
 
 func (m *mysql) LastInsertIDSQL(_, _ string) (sql string, append bool) { return "", false }
 
 func (m *mysql) VersionSQL() string { return `select version();` }
 
+// WithHook mysql>=8.0 才支持 WITH 语句，mariadb 不支持在 UPDATE 和 DELETE 中使用 WITH。
+func (m *mysql) WithHook(e core.Engine) error {
+	if m.isMariadb {
+		return errors.New("mariadb 不支持在 UPDATE 和 DELETE 中使用 WITH")
+	}
+
+	ver, err := sqlbuilder.Version(e)
+	if err != nil {
+		return err
+	}
+	if !versionAtLeast(ver, 8) {
+		return fmt.Errorf("mysql %s 不支持 WITH 语句", ver)
+	}
+	return nil
+}
+
 func (m *mysql) Prepare(query string) (string, map[string]int, error) { return PrepareNamedArgs(query) }
 
+func (m *mysql) Literal(v any) (string, error) {
+	return literal(m.Name(), v, func(v driver.Value) (string, bool) {
+		switch vv := v.(type) {
+		case bool:
+			if vv {
+				return "TRUE", true
+			}
+			return "FALSE", true
+		case string:
+			return "'" + escapeApostrophe.Replace(vv) + "'", true
+		case []byte:
+			return hexLiteral(vv), true
+		case time.Time:
+			return timeLiteral(vv), true
+		}
+		return "", false
+	})
+}
+
 func (m *mysql) CreateTableOptionsSQL(w *core.Builder, options map[string][]string) error {
 	if len(options[mysqlEngine]) == 1 {
 		engine := options[mysqlEngine][0]
@@ -124,6 +168,17 @@ func (m *mysql) DropIndexSQL(table, index string) (string, error) {
 		String()
 }
 
+// 获取锁时等待的秒数，mariadb 不支持以负数表示无限等待。
+const mysqlLockTimeout = 365 * 24 * 60 * 60
+
+func (m *mysql) LockSQL(name string) (string, []any) {
+	return "SELECT GET_LOCK(?,?)", []any{name, mysqlLockTimeout}
+}
+
+func (m *mysql) UnlockSQL(name string) (string, []any) {
+	return "SELECT RELEASE_LOCK(?)", []any{name}
+}
+
 func (m *mysql) TruncateTableSQL(table, _ string) ([]string, error) {
 	builder := core.NewBuilder("TRUNCATE TABLE ").QuoteKey(table)
 
@@ -152,6 +207,26 @@ func (m *mysql) CreateViewSQL(replace, temporary bool, name, selectQuery string,
 	return []string{q}, nil
 }
 
+// AlterColumnStmtHook 表中已经存在主键，自增列不能再次声明 PRIMARY KEY。
+func (m *mysql) AlterColumnStmtHook(stmt *sqlbuilder.AlterColumnStmt) ([]string, error) {
+	typ, err := m.sqlType(stmt.Col, false)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := core.NewBuilder("ALTER TABLE ").
+		QuoteKey(stmt.TableName).
+		WString(" MODIFY COLUMN ").
+		QuoteKey(stmt.Col.Name).
+		WBytes(' ').
+		WString(typ).
+		String()
+	if err != nil {
+		return nil, err
+	}
+	return []string{query}, nil
+}
+
 func (m *mysql) InsertDefaultValueHook(table string) (string, []any, error) {
 	query, err := core.NewBuilder("INSERT INTO").
 		QuoteKey(table).
@@ -164,8 +239,67 @@ func (m *mysql) InsertDefaultValueHook(table string) (string, []any, error) {
 	return query, nil, nil
 }
 
+func (m *mysql) UpdateJoinSyntax(core.Engine) sqlbuilder.JoinSyntax { return sqlbuilder.JoinMysql }
+
+func (m *mysql) DeleteJoinSyntax(core.Engine) sqlbuilder.JoinSyntax { return sqlbuilder.JoinMysql }
+
+func (m *mysql) UpdateDeleteLimitHook() (bool, string) { return true, "" }
+
 func (m *mysql) TransactionalDDL() bool { return m.innoDB }
 
+// JSONExtractSQL JSON_EXTRACT 返回的是 JSON 值，需要由 JSON_UNQUOTE 转换成文本。
+func (m *mysql) JSONExtractSQL(col string, path ...string) (string, []any, error) {
+	query, err := core.NewBuilder("JSON_UNQUOTE(JSON_EXTRACT(").
+		QuoteColumn(col).
+		WString(", ?))").
+		String()
+	if err != nil {
+		return "", nil, err
+	}
+	return query, []any{jsonPath(path)}, nil
+}
+
+func (m *mysql) JSONContainsSQL(col string) (string, error) {
+	return core.NewBuilder("JSON_CONTAINS(").QuoteColumn(col).WString(", ?)").String()
+}
+
+func (m *mysql) EnumSQLType(_ string, col *core.Column) (string, []string, error) {
+	w := core.NewBuilder("ENUM(")
+	if err := sqlbuilder.EnumValues(m, w, col); err != nil {
+		return "", nil, err
+	}
+	typ, err := w.WBytes(')').String()
+	if err != nil {
+		return "", nil, err
+	}
+
+	typ, err = m.buildType(typ, col, false, 0, false)
+	return typ, nil, err
+}
+
+// AlterEnumSQL mysql 的 ENUM 是列类型的一部分，直接修改列的定义即可。
+func (m *mysql) AlterEnumSQL(table string, col *core.Column) ([]string, error) {
+	typ, _, err := m.EnumSQLType(table, col)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := core.NewBuilder("ALTER TABLE ").
+		QuoteKey(table).
+		WString(" MODIFY COLUMN ").
+		QuoteKey(col.Name).
+		WBytes(' ').
+		WString(typ).
+		String()
+	if err != nil {
+		return nil, err
+	}
+	return []string{query}, nil
+}
+
+// DropEnumSQL mysql 的 ENUM 是列类型的一部分，不需要额外的操作。
+func (m *mysql) DropEnumSQL(string, string) ([]string, error) { return nil, nil }
+
 func (m *mysql) ExistsSQL(name string, view bool) (string, []any) {
 	t := "BASE TABLE"
 	if view {
@@ -174,61 +308,70 @@ func (m *mysql) ExistsSQL(name string, view bool) (string, []any) {
 	return "SELECT TABLE_NAME as name FROM information_schema.tables WHERE TABLE_TYPE=? AND TABLE_NAME=?", []any{t, name}
 }
 
-func (m *mysql) SQLType(col *core.Column) (string, error) {
+func (m *mysql) SQLType(col *core.Column) (string, error) { return m.sqlType(col, true) }
+
+// pk 表示自增列是否需要同时声明 PRIMARY KEY
+func (m *mysql) sqlType(col *core.Column, pk bool) (string, error) {
 	if col == nil {
 		return "", errColIsNil
 	}
 
-	switch col.PrimitiveType {
+	typ, primitiveType := customSQLType(m.Name(), col)
+	if typ != "" {
+		return m.buildType(typ, col, false, 0, pk)
+	}
+
+	switch primitiveType {
 	case core.Bool:
-		return m.buildType("BOOLEAN", col, false, 0)
+		return m.buildType("BOOLEAN", col, false, 0, pk)
 	case core.Int8:
-		return m.buildType("SMALLINT", col, false, 1)
+		return m.buildType("SMALLINT", col, false, 1, pk)
 	case core.Int16:
-		return m.buildType("MEDIUMINT", col, false, 1)
+		return m.buildType("MEDIUMINT", col, false, 1, pk)
 	case core.Int32:
-		return m.buildType("INT", col, false, 1)
+		return m.buildType("INT", col, false, 1, pk)
 	case core.Int64, core.Int: // reflect.Int 大小未知，都当作是 BIGINT 处理
-		return m.buildType("BIGINT", col, false, 1)
+		return m.buildType("BIGINT", col, false, 1, pk)
 	case core.Uint8:
-		return m.buildType("SMALLINT", col, true, 1)
+		return m.buildType("SMALLINT", col, true, 1, pk)
 	case core.Uint16:
-		return m.buildType("MEDIUMINT", col, true, 1)
+		return m.buildType("MEDIUMINT", col, true, 1, pk)
 	case core.Uint32:
-		return m.buildType("INT", col, true, 1)
+		return m.buildType("INT", col, true, 1, pk)
 	case core.Uint64, core.Uint:
-		return m.buildType("BIGINT", col, true, 1)
+		return m.buildType("BIGINT", col, true, 1, pk)
 	case core.Float32:
-		return m.buildType("FLOAT", col, false, 0)
+		return m.buildType("FLOAT", col, false, 0, pk)
 	case core.Float64:
-		return m.buildType("DOUBLE PRECISION", col, false, 0)
+		return m.buildType("DOUBLE PRECISION", col, false, 0, pk)
 	case core.Decimal:
 		if len(col.Length) != 2 {
 			return "", missLength(col)
 		}
-		return m.buildType("DECIMAL", col, false, 2)
+		return m.buildType("DECIMAL", col, false, 2, pk)
 	case core.String:
 		if len(col.Length) == 0 || col.Length[0] == -1 || col.Length[0] > 65533 {
-			return m.buildType("LONGTEXT", col, false, 0)
+			return m.buildType("LONGTEXT", col, false, 0, pk)
 		}
-		return m.buildType("VARCHAR", col, false, 1)
+		return m.buildType("VARCHAR", col, false, 1, pk)
 	case core.Bytes:
-		return m.buildType("BLOB", col, false, 0)
+		return m.buildType("BLOB", col, false, 0, pk)
 	case core.Time:
 		if len(col.Length) == 0 {
-			return m.buildType("DATETIME", col, false, 0)
+			return m.buildType("DATETIME", col, false, 0, pk)
 		}
 		if col.Length[0] < 0 || col.Length[0] > 6 {
 			return "", invalidTimeFractional(col)
 		}
-		return m.buildType("DATETIME", col, false, 1)
+		return m.buildType("DATETIME", col, false, 1, pk)
 	default:
 		return "", errUncovert(col)
 	}
 }
 
-// l 表示需要取的长度数量
-func (m *mysql) buildType(typ string, col *core.Column, unsigned bool, l int) (string, error) {
+// l 表示需要取的长度数量；
+// pk 表示自增列是否需要同时声明 PRIMARY KEY，修改已有的列时，表中已经存在主键，不能再次声明。
+func (m *mysql) buildType(typ string, col *core.Column, unsigned bool, l int, pk bool) (string, error) {
 	w := core.NewBuilder(typ)
 
 	switch {
@@ -247,7 +390,10 @@ func (m *mysql) buildType(typ string, col *core.Column, unsigned bool, l int) (s
 	}
 
 	if col.AI {
-		w.WString(" PRIMARY KEY AUTO_INCREMENT")
+		if pk {
+			w.WString(" PRIMARY KEY")
+		}
+		w.WString(" AUTO_INCREMENT")
 	}
 
 	if !col.Nullable {
@@ -266,6 +412,10 @@ func (m *mysql) buildType(typ string, col *core.Column, unsigned bool, l int) (s
 }
 
 func (m *mysql) formatSQL(col *core.Column) (f string, err error) {
+	if v, found, err := customDefault(m.Name(), col); found {
+		return v, err
+	}
+
 	v := col.Default
 	if vv, ok := v.(driver.Valuer); ok {
 		v, err = vv.Value()
@@ -349,3 +499,246 @@ func (m *mysql) Backup(dsn, dest string) error {
 	err = cmd.Run() // defer 需要用到
 	return err
 }
+
+func (m *mysql) Tables(e core.Engine) ([]string, error) {
+	return queryStrings(e, "SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA=DATABASE() AND TABLE_TYPE='BASE TABLE' ORDER BY TABLE_NAME")
+}
+
+func (m *mysql) Introspect(e core.Engine, table string) (*core.Model, error) {
+	model := core.NewModel(core.Table, table, 10)
+
+	if err := m.introspectColumns(e, model, table); err != nil {
+		return nil, err
+	}
+
+	fks, err := m.introspectForeignKeys(e, model, table)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.introspectIndexes(e, model, table, fks); err != nil {
+		return nil, err
+	}
+
+	if err := m.introspectChecks(e, model, table); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (m *mysql) introspectColumns(e core.Engine, model *core.Model, table string) (err error) {
+	const query = `SELECT COLUMN_NAME,DATA_TYPE,COLUMN_TYPE,IS_NULLABLE,COLUMN_DEFAULT,EXTRA,
+	CHARACTER_MAXIMUM_LENGTH,NUMERIC_PRECISION,NUMERIC_SCALE,DATETIME_PRECISION
+	FROM information_schema.COLUMNS WHERE TABLE_SCHEMA=DATABASE() AND TABLE_NAME=? ORDER BY ORDINAL_POSITION`
+
+	rows, err := e.Query(query, table)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, rows.Close()) }()
+
+	for rows.Next() {
+		var (
+			name, dataType, colType, nullable, extra string
+			def                                      sql.NullString
+			charLen, precision, scale, timePrecision sql.NullInt64
+		)
+		if err = rows.Scan(&name, &dataType, &colType, &nullable, &def, &extra, &charLen, &precision, &scale, &timePrecision); err != nil {
+			return err
+		}
+
+		col := &core.Column{Name: name, Nullable: nullable == "YES"}
+		unsigned := strings.Contains(strings.ToLower(colType), "unsigned")
+		switch strings.ToLower(dataType) {
+		case "tinyint":
+			if strings.HasPrefix(strings.ToLower(colType), "tinyint(1)") {
+				col.PrimitiveType = core.Bool
+			} else {
+				col.PrimitiveType = unsignedType(core.Int8, unsigned)
+			}
+		case "smallint":
+			col.PrimitiveType = unsignedType(core.Int16, unsigned)
+		case "mediumint", "int", "integer":
+			col.PrimitiveType = unsignedType(core.Int32, unsigned)
+		case "bigint":
+			col.PrimitiveType = unsignedType(core.Int64, unsigned)
+		case "bit", "bool", "boolean":
+			col.PrimitiveType = core.Bool
+		case "float":
+			col.PrimitiveType = core.Float32
+		case "double", "real":
+			col.PrimitiveType = core.Float64
+		case "decimal", "numeric":
+			col.PrimitiveType = core.Decimal
+			col.Length = []int{int(precision.Int64), int(scale.Int64)}
+		case "char", "varchar":
+			col.PrimitiveType = core.String
+			col.Length = []int{int(charLen.Int64)}
+		case "date", "datetime", "timestamp", "time", "year":
+			col.PrimitiveType = core.Time
+			if timePrecision.Int64 > 0 {
+				col.Length = []int{int(timePrecision.Int64)}
+			}
+		case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+			col.PrimitiveType = core.Bytes
+		default: // text、json、enum 等都当作字符串处理
+			col.PrimitiveType = core.String
+			col.Length = []int{-1}
+		}
+
+		// mariadb 中 NULL 默认值会以字符串 NULL 的形式返回，也可以由 parseDefault 过滤。
+		// mysql 中 DEFAULT_GENERATED 表示默认值是表达式。
+		if def.Valid && !strings.Contains(strings.ToUpper(extra), "DEFAULT_GENERATED") {
+			if v, ok := parseDefault(def.String); ok {
+				col.Default, col.HasDefault = v, true
+			} else if col.PrimitiveType == core.String && !strings.EqualFold(def.String, "NULL") {
+				col.Default, col.HasDefault = def.String, true // mysql 的字符串默认值不带引号
+			}
+		}
+
+		if err = model.AddColumn(col); err != nil {
+			return err
+		}
+
+		if strings.Contains(strings.ToLower(extra), "auto_increment") {
+			if err = model.SetAutoIncrement(col); err != nil {
+				return err
+			}
+		}
+	}
+
+	return rows.Err()
+}
+
+// fks 为外键约束的名称，mysql 会为外键自动创建同名的索引，需要过滤掉。
+func (m *mysql) introspectIndexes(e core.Engine, model *core.Model, table string, fks []string) (err error) {
+	const query = `SELECT INDEX_NAME,NON_UNIQUE,COLUMN_NAME FROM information_schema.STATISTICS
+	WHERE TABLE_SCHEMA=DATABASE() AND TABLE_NAME=? ORDER BY INDEX_NAME,SEQ_IN_INDEX`
+
+	rows, err := e.Query(query, table)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, rows.Close()) }()
+
+	for rows.Next() {
+		var (
+			name, colName string
+			nonUnique     int
+		)
+		if err = rows.Scan(&name, &nonUnique, &colName); err != nil {
+			return err
+		}
+
+		col := model.FindColumn(colName)
+		if col == nil {
+			return core.ErrColumnNotFound(colName)
+		}
+
+		switch {
+		case name == "PRIMARY":
+			if !col.AI {
+				err = model.AddPrimaryKey(col)
+			}
+		case nonUnique == 0:
+			err = model.AddUnique(name, col)
+		default:
+			if !slices.Contains(fks, name) {
+				err = model.AddIndex(core.IndexDefault, name, col)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (m *mysql) introspectForeignKeys(e core.Engine, model *core.Model, table string) (names []string, err error) {
+	const query = `SELECT k.CONSTRAINT_NAME,k.COLUMN_NAME,k.REFERENCED_TABLE_NAME,k.REFERENCED_COLUMN_NAME,r.UPDATE_RULE,r.DELETE_RULE
+	FROM information_schema.KEY_COLUMN_USAGE AS k
+	JOIN information_schema.REFERENTIAL_CONSTRAINTS AS r ON r.CONSTRAINT_SCHEMA=k.CONSTRAINT_SCHEMA AND r.CONSTRAINT_NAME=k.CONSTRAINT_NAME
+	WHERE k.TABLE_SCHEMA=DATABASE() AND k.TABLE_NAME=? AND k.REFERENCED_TABLE_NAME IS NOT NULL AND k.ORDINAL_POSITION=1
+	ORDER BY k.CONSTRAINT_NAME`
+
+	rows, err := e.Query(query, table)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errors.Join(err, rows.Close()) }()
+
+	for rows.Next() {
+		var name, colName, refTable, refCol, update, del string
+		if err = rows.Scan(&name, &colName, &refTable, &refCol, &update, &del); err != nil {
+			return nil, err
+		}
+
+		col := model.FindColumn(colName)
+		if col == nil {
+			return nil, core.ErrColumnNotFound(colName)
+		}
+
+		err = model.NewForeignKey(&core.ForeignKey{
+			Name:         name,
+			Column:       col,
+			RefTableName: refTable,
+			RefColName:   refCol,
+			UpdateRule:   fkRule(update),
+			DeleteRule:   fkRule(del),
+		})
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+// 需要 mysql>=8.0.16 或是 mariadb>=10.2.1
+func (m *mysql) introspectChecks(e core.Engine, model *core.Model, table string) (err error) {
+	query := `SELECT tc.CONSTRAINT_NAME,cc.CHECK_CLAUSE FROM information_schema.TABLE_CONSTRAINTS AS tc
+	JOIN information_schema.CHECK_CONSTRAINTS AS cc ON cc.CONSTRAINT_SCHEMA=tc.CONSTRAINT_SCHEMA AND cc.CONSTRAINT_NAME=tc.CONSTRAINT_NAME
+	WHERE tc.TABLE_SCHEMA=DATABASE() AND tc.TABLE_NAME=? AND tc.CONSTRAINT_TYPE='CHECK'`
+	if m.isMariadb { // mariadb 的 CHECK_CONSTRAINTS 本身就包含了 TABLE_NAME
+		query = `SELECT CONSTRAINT_NAME,CHECK_CLAUSE FROM information_schema.CHECK_CONSTRAINTS
+		WHERE CONSTRAINT_SCHEMA=DATABASE() AND TABLE_NAME=?`
+	}
+
+	rows, err := e.Query(query, table)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, rows.Close()) }()
+
+	for rows.Next() {
+		var name, expr string
+		if err = rows.Scan(&name, &expr); err != nil {
+			return err
+		}
+		if err = model.NewCheck(name, trimParens(expr)); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func unsignedType(t core.PrimitiveType, unsigned bool) core.PrimitiveType {
+	if !unsigned {
+		return t
+	}
+
+	switch t {
+	case core.Int8:
+		return core.Uint8
+	case core.Int16:
+		return core.Uint16
+	case core.Int32:
+		return core.Uint32
+	default:
+		return core.Uint64
+	}
+}
@@ -5,8 +5,10 @@
 package dialect_test
 
 import (
+	"database/sql"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/issue9/assert/v4"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/issue9/orm/v6/internal/sqltest"
 	"github.com/issue9/orm/v6/internal/test"
 	"github.com/issue9/orm/v6/sqlbuilder"
+	"github.com/issue9/orm/v6/types"
 )
 
 func TestMysql_VersionSQL(t *testing.T) {
@@ -69,6 +72,56 @@ func TestMysql_DropConstrainStmtHook(t *testing.T) {
 	})
 }
 
+func TestMysql_WithHook(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "", test.Mysql, test.Mariadb)
+
+	suite.Run(func(t *test.Driver) {
+		sub := sqlbuilder.Select(t.DB).Column("id").From("info")
+		_, _, err := sqlbuilder.Update(t.DB).
+			With("sub", sub).
+			Table("info").
+			Set("name", "n").
+			Where("id IN (SELECT id FROM sub)").
+			SQL()
+
+		if t.DB.Dialect().Name() == "mariadb" {
+			t.Error(err)
+		} else {
+			t.NotError(err)
+		}
+	})
+}
+
+func TestMysql_AlterColumnStmtHook(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "", test.Mysql, test.Mariadb)
+
+	suite.Run(func(t *test.Driver) {
+		db := t.DB
+
+		_, err := db.Exec("CREATE TABLE alter_col(id BIGINT NOT NULL PRIMARY KEY AUTO_INCREMENT,name VARCHAR(20) NOT NULL)")
+		t.NotError(err)
+		defer func() {
+			_, err = db.Exec("DROP TABLE alter_col")
+			t.NotError(err)
+		}()
+
+		stmt := sqlbuilder.AlterColumn(db).Table("alter_col").Column("name", core.String, false, true, false, nil, 50)
+		qs, err := stmt.DDLSQL()
+		t.NotError(err).Length(qs, 1)
+		sqltest.Equal(a, qs[0], "ALTER TABLE {alter_col} MODIFY COLUMN {name} VARCHAR(50)")
+		t.NotError(stmt.Exec())
+
+		// 自增列不能再次声明 PRIMARY KEY
+		stmt = sqlbuilder.AlterColumn(db).Table("alter_col").Column("id", core.Int64, true, false, false, nil)
+		qs, err = stmt.DDLSQL()
+		t.NotError(err).Length(qs, 1)
+		sqltest.Equal(a, qs[0], "ALTER TABLE {alter_col} MODIFY COLUMN {id} BIGINT AUTO_INCREMENT NOT NULL")
+		t.NotError(stmt.Exec())
+	})
+}
+
 func TestMysql_DropIndexSQL(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -91,6 +144,50 @@ func TestMysql_TruncateTableSQL(t *testing.T) {
 	})
 }
 
+func TestMysql_LockSQL(t *testing.T) {
+	a := assert.New(t, false)
+	l, ok := dialect.Mysql("mysql_driver_name").(core.AdvisoryLocker)
+	a.True(ok)
+
+	query, args := l.LockSQL("lock")
+	a.Equal(query, "SELECT GET_LOCK(?,?)").Equal(args[0], "lock")
+
+	query, args = l.UnlockSQL("lock")
+	a.Equal(query, "SELECT RELEASE_LOCK(?)").Equal(args, []any{"lock"})
+}
+
+func TestMysql_Literal(t *testing.T) {
+	a := assert.New(t, false)
+	d := dialect.Mysql("mysql_driver_name")
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.FixedZone("UTC+8", 8*3600))
+
+	data := []struct {
+		v   any
+		out string
+	}{
+		{v: nil, out: "NULL"},
+		{v: 5, out: "5"},
+		{v: uint8(5), out: "5"},
+		{v: 1.5, out: "1.5"},
+		{v: true, out: "TRUE"},
+		{v: false, out: "FALSE"},
+		{v: "abc", out: "'abc'"},
+		{v: `a'b\c`, out: `'a\'b\\c'`},
+		{v: "a\nb\x00", out: `'a\nb\0'`},
+		{v: []byte{0x0a, 0xff}, out: "X'0aff'"},
+		{v: now, out: "'2024-01-01 19:04:05.000006'"},
+		{v: sql.NullString{}, out: "NULL"},
+		{v: sql.NullInt64{Valid: true, Int64: 5}, out: "5"},
+	}
+	for _, item := range data {
+		out, err := d.Literal(item.v)
+		a.NotError(err).Equal(out, item.out, "%v", item.v)
+	}
+
+	out, err := d.Literal(struct{}{})
+	a.Error(err).Empty(out)
+}
+
 func TestMysql_CreateTableOptions(t *testing.T) {
 	a := assert.New(t, false)
 	builder := core.NewBuilder("")
@@ -296,3 +393,88 @@ func TestMysql_Backup(t *testing.T) {
 		d.Assertion.NotError(os.Remove(path))
 	})
 }
+
+func TestMysql_JSON(t *testing.T) {
+	a := assert.New(t, false)
+
+	testSQLType(a, dialect.Mysql("mysql"), []*sqlTypeTester{
+		{
+			col:     &core.Column{PrimitiveType: types.JSONType},
+			SQLType: "JSON NOT NULL",
+		},
+	})
+
+	db := newOfflineDB(dialect.Mysql("mysql"))
+	defer func() { a.NotError(db.Close()) }()
+	sb := db.SQLBuilder()
+
+	query, args, err := db.Render(sb.Select().
+		JSONColumn("data", "name", "user", "name").
+		From("#users").
+		AndJSON("data", "=", "abc", "tags", "0").
+		AndJSONContains("data", map[string]int{"a": 1}))
+	a.NotError(err).Equal(args, []any{`$."user"."name"`, `$."tags"[0]`, "abc", `{"a":1}`})
+	sqltest.Equal(a, query, "SELECT JSON_UNQUOTE(JSON_EXTRACT(`data`, ?)) AS `name` FROM `p_users` WHERE  JSON_UNQUOTE(JSON_EXTRACT(`data`, ?)) = ? AND JSON_CONTAINS(`data`, ?)")
+
+	// 键名中的双引号
+	_, args, err = db.Render(sb.Update().Table("#users").Set("name", "n").AndJSON("data", "=", 1, `a"b`))
+	a.NotError(err).Equal(args, []any{"n", `$."a\"b"`, 1})
+}
+
+func TestMysql_UUID(t *testing.T) {
+	a := assert.New(t, false)
+	m := dialect.Mysql("mysql")
+
+	testSQLType(a, m, []*sqlTypeTester{
+		{
+			col:     &core.Column{PrimitiveType: types.UUIDType},
+			SQLType: "BINARY(16) NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: types.TextUUIDType},
+			SQLType: "CHAR(36) NOT NULL",
+		},
+	})
+
+	u, err := types.ParseUUID("0190a6e4-3c5b-7f6e-8a1b-2c3d4e5f6a7b")
+	a.NotError(err)
+
+	_, args, err := m.Fix("SELECT * FROM tbl WHERE id=?", []any{u})
+	a.NotError(err).Equal(args, []any{u[:]})
+}
+
+func TestMysql_Enum(t *testing.T) {
+	a := assert.New(t, false)
+	state := &core.Column{Name: "state", PrimitiveType: core.String, HasDefault: true, Default: "draft", Enums: []any{"draft", "it's"}}
+	level := &core.Column{Name: "level", PrimitiveType: core.Int8, Enums: []any{1, 2}}
+
+	db := newOfflineDB(dialect.Mysql("mysql"))
+	defer func() { a.NotError(db.Close()) }()
+	sb := db.SQLBuilder()
+
+	qs, err := db.RenderDDL(sb.CreateTable().Table("#users").Columns(state, level))
+	a.NotError(err).Length(qs, 1)
+	sqltest.Equal(a, qs[0], "CREATE TABLE IF NOT EXISTS `p_users`("+
+		"`state` ENUM('draft','it\\'s') NOT NULL DEFAULT 'draft',"+
+		"`level` SMALLINT NOT NULL,"+
+		"CONSTRAINT `p_users_level_enum` CHECK(`level` IN(1,2)))")
+
+	qs, err = db.RenderDDL(sb.AlterEnum().Table("#users").Column(state))
+	a.NotError(err).Length(qs, 1)
+	sqltest.Equal(a, qs[0], "ALTER TABLE `p_users` MODIFY COLUMN `state` ENUM('draft','it\\'s') NOT NULL DEFAULT 'draft'")
+
+	_, err = db.RenderDDL(sb.AlterEnum().Table("#users").Column(&core.Column{Name: "state", PrimitiveType: core.String}))
+	a.Error(err)
+
+	// 原生枚举不能通过 AlterColumn 修改
+	_, err = db.RenderDDL(sb.AlterColumn().Table("#users").
+		Column("state", core.String, false, false, false, nil).
+		Enums("draft"))
+	a.Error(err)
+
+	qs, err = db.RenderDDL(sb.AlterColumn().Table("#users").
+		Column("level", core.Int8, false, true, false, nil).
+		Enums(1, 2))
+	a.NotError(err).Length(qs, 1)
+	sqltest.Equal(a, qs[0], "ALTER TABLE `p_users` MODIFY COLUMN `level` SMALLINT")
+}
@@ -0,0 +1,395 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package dialect
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/issue9/orm/v6/core"
+	"github.com/issue9/orm/v6/sqlbuilder"
+)
+
+// oracle 中表已经存在和表不存在的错误代码
+const (
+	oracleErrExists    = -955
+	oracleErrNotExists = -942
+)
+
+type oracle struct {
+	base
+}
+
+var (
+	_ sqlbuilder.CreateTableIfNotExistsHooker = &oracle{}
+	_ sqlbuilder.DropTableIfExistsHooker      = &oracle{}
+	_ sqlbuilder.ReturningIntoHooker          = &oracle{}
+	_ sqlbuilder.AddConstraintStmtHooker      = &oracle{}
+	_ sqlbuilder.AlterColumnStmtHooker        = &oracle{}
+	_ sqlbuilder.WithHooker                   = &oracle{}
+	_ core.ScriptFormatter                    = &oracle{}
+)
+
+// Oracle 返回一个适配 oracle 的 [core.Dialect] 接口
+//
+// 最低要求 oracle 12c，参数采用 :1、:2 的形式，适用于 github.com/godror/godror 等驱动。
+//
+// NOTE: oracle 的外键不支持 ON UPDATE，ON DELETE 也仅支持 CASCADE 和 SET NULL；
+// DDL 语句会自动提交事务，所以 [core.Dialect.TransactionalDDL] 始终返回 false。
+func Oracle(driverName string) core.Dialect {
+	return &oracle{
+		base: newBase("oracle", driverName, '"', '"'),
+	}
+}
+
+func (o *oracle) VersionSQL() string {
+	return `SELECT VERSION FROM PRODUCT_COMPONENT_VERSION WHERE ROWNUM=1`
+}
+
+func (o *oracle) Fix(query string, args []any) (string, []any, error) {
+	query, args, err := fixQueryAndArgs(o.Name(), query, args)
+	if err != nil {
+		return "", nil, err
+	}
+	return o.replace(query), args, nil
+}
+
+func (o *oracle) Prepare(query string) (string, map[string]int, error) {
+	query, orders, err := PrepareNamedArgs(query)
+	if err != nil {
+		return "", nil, err
+	}
+	return o.replace(query), orders, nil
+}
+
+// 将 ? 替换成 :1、:2 的形式
+func (o *oracle) replace(query string) string {
+	if strings.IndexByte(query, '?') < 0 {
+		return query
+	}
+
+	num := 1
+	build := strings.Builder{}
+	for _, c := range query {
+		if c == '?' {
+			build.WriteByte(':')
+			build.WriteString(strconv.Itoa(num))
+			num++
+			continue
+		}
+		build.WriteRune(c)
+	}
+	return build.String()
+}
+
+func (o *oracle) Literal(v any) (string, error) {
+	return literal(o.Name(), v, func(v driver.Value) (string, bool) {
+		switch vv := v.(type) {
+		case bool:
+			if vv {
+				return "1", true
+			}
+			return "0", true
+		case string:
+			return "'" + quoteApostrophe.Replace(vv) + "'", true
+		case []byte:
+			return "HEXTORAW('" + hex.EncodeToString(vv) + "')", true
+		case time.Time:
+			return "TIMESTAMP " + timeLiteral(vv), true
+		}
+		return "", false
+	})
+}
+
+// LastInsertIDSQL 由 [oracle.ReturningIntoHook] 实现
+func (o *oracle) LastInsertIDSQL(_, _ string) (sql string, append bool) { return "", false }
+
+func (o *oracle) ReturningIntoHook(col string, dest *int64) (string, any) {
+	return " RETURNING {" + col + "} INTO ?", sql.Out{Dest: dest}
+}
+
+func (o *oracle) CreateTableOptionsSQL(*core.Builder, map[string][]string) error { return nil }
+
+func (o *oracle) LimitSQL(limit any, offset ...any) (string, []any) {
+	query, args := oracleLimitSQL(limit, offset...)
+	return " " + query, args
+}
+
+func (o *oracle) CreateTableIfNotExistsHook(_, query string) (string, error) {
+	return oracleIgnoreError(query, oracleErrExists), nil
+}
+
+func (o *oracle) DropTableIfExistsHook(_, query string) (string, error) {
+	return oracleIgnoreError(query, oracleErrNotExists), nil
+}
+
+// 以 PL/SQL 执行 query 并忽略错误代码为 code 的错误
+func oracleIgnoreError(query string, code int) string {
+	return "BEGIN EXECUTE IMMEDIATE '" + quoteApostrophe.Replace(query) + "'; " +
+		"EXCEPTION WHEN OTHERS THEN IF SQLCODE != " + strconv.Itoa(code) + " THEN RAISE; END IF; END;"
+}
+
+// ScriptStatement PL/SQL 块本身以 END; 结尾，在脚本中需要以单独一行的 / 结束。
+func (o *oracle) ScriptStatement(query string) string {
+	if strings.HasPrefix(query, "BEGIN ") {
+		return query + "\n/"
+	}
+	return query + ";"
+}
+
+// ScriptTransaction oracle 会在第一条语句执行时自动开启事务，不需要 BEGIN 语句。
+func (o *oracle) ScriptTransaction() (begin, commit string) { return "", "COMMIT;" }
+
+// WithHook oracle 的 WITH 只能用于 SELECT 语句
+func (o *oracle) WithHook(core.Engine) error {
+	return errors.New("oracle 不支持在 UPDATE 和 DELETE 中使用 WITH")
+}
+
+func (o *oracle) AddConstraintStmtHook(stmt *sqlbuilder.AddConstraintStmt) ([]string, error) {
+	builder := core.NewBuilder("ALTER TABLE ").
+		QuoteKey(stmt.TableName).
+		WString(" ADD CONSTRAINT ").
+		QuoteKey(stmt.Name)
+
+	switch stmt.Type {
+	case core.ConstraintCheck:
+		builder.WString(" CHECK(").WString(stmt.Data[0]).WBytes(')')
+	case core.ConstraintFK:
+		if rule := strings.ToUpper(stmt.Data[3]); rule != "" && rule != "NO ACTION" {
+			return nil, errors.New("oracle 的外键不支持 ON UPDATE")
+		}
+
+		builder.WString(" FOREIGN KEY(").
+			QuoteKey(stmt.Data[0]).
+			WString(") REFERENCES ").
+			QuoteKey(stmt.Data[1]).
+			WBytes('(').
+			QuoteKey(stmt.Data[2]).
+			WBytes(')')
+
+		switch rule := strings.ToUpper(stmt.Data[4]); rule {
+		case "", "NO ACTION", "RESTRICT": // 默认行为
+		case "CASCADE", "SET NULL":
+			builder.WString(" ON DELETE ").WString(rule)
+		default:
+			return nil, errors.New("oracle 的外键不支持 ON DELETE " + rule)
+		}
+	case core.ConstraintPK, core.ConstraintUnique:
+		if stmt.Type == core.ConstraintPK {
+			builder.WString(" PRIMARY KEY(")
+		} else {
+			builder.WString(" UNIQUE(")
+		}
+		for _, col := range stmt.Data {
+			builder.QuoteKey(col).WBytes(',')
+		}
+		builder.TruncateLast(1).WBytes(')')
+	default:
+		return nil, errors.New("未知的约束类型")
+	}
+
+	query, err := builder.String()
+	if err != nil {
+		return nil, err
+	}
+	return []string{query}, nil
+}
+
+// AlterColumnStmtHook 采用 MODIFY 语法修改列
+func (o *oracle) AlterColumnStmtHook(stmt *sqlbuilder.AlterColumnStmt) ([]string, error) {
+	col := stmt.Col.Clone()
+	col.AI = false // 标识列只能用于创建列
+
+	// MODIFY 不会清除原有的默认值，需要显式指定为 NULL
+	if !col.HasDefault {
+		col.HasDefault = true
+		col.Default = nil
+	}
+	typ, err := o.SQLType(col)
+	if err != nil {
+		return nil, err
+	}
+	if col.Nullable {
+		typ += " NULL"
+	}
+
+	query, err := core.NewBuilder("ALTER TABLE ").
+		QuoteKey(stmt.TableName).
+		WString(" MODIFY (").
+		QuoteKey(stmt.Col.Name).
+		WBytes(' ').
+		WString(typ).
+		WBytes(')').
+		String()
+	if err != nil {
+		return nil, err
+	}
+	return []string{query}, nil
+}
+
+// TruncateTableSQL 清空表并将标识列从 1 开始重新计数
+func (o *oracle) TruncateTableSQL(table, ai string) ([]string, error) {
+	query, err := core.NewBuilder("TRUNCATE TABLE ").QuoteKey(table).String()
+	if err != nil {
+		return nil, err
+	}
+	qs := []string{query}
+
+	if ai != "" {
+		query, err = core.NewBuilder("ALTER TABLE ").
+			QuoteKey(table).
+			WString(" MODIFY ").
+			QuoteKey(ai).
+			WString(" GENERATED BY DEFAULT AS IDENTITY (START WITH 1)").
+			String()
+		if err != nil {
+			return nil, err
+		}
+		qs = append(qs, query)
+	}
+
+	return qs, nil
+}
+
+func (o *oracle) CreateViewSQL(replace, temporary bool, name, selectQuery string, cols []string) ([]string, error) {
+	if temporary {
+		return nil, errors.New("oracle 不支持临时视图")
+	}
+
+	builder := core.NewBuilder("CREATE ")
+	if replace {
+		builder.WString(" OR REPLACE ")
+	}
+
+	q, err := appendViewBody(builder, name, selectQuery, cols)
+	if err != nil {
+		return nil, err
+	}
+	return []string{q}, nil
+}
+
+func (o *oracle) TransactionalDDL() bool { return false }
+
+func (o *oracle) DropIndexSQL(_, index string) (string, error) { return stdDropIndex(index) }
+
+func (o *oracle) ExistsSQL(name string, view bool) (string, []any) {
+	if view {
+		return "SELECT VIEW_NAME AS name FROM USER_VIEWS WHERE VIEW_NAME=?", []any{name}
+	}
+	return "SELECT TABLE_NAME AS name FROM USER_TABLES WHERE TABLE_NAME=?", []any{name}
+}
+
+// Backup oracle 的 expdp 只能将备份文件写入服务端的目录，请直接使用 expdp 等工具。
+func (o *oracle) Backup(string, string) error {
+	return errors.New("oracle 不支持 Backup 操作")
+}
+
+func (o *oracle) SQLType(col *core.Column) (string, error) {
+	if col == nil {
+		return "", errColIsNil
+	}
+
+	typ, primitiveType := customSQLType(o.Name(), col)
+	if typ != "" {
+		return o.buildType(typ, col, 0)
+	}
+
+	switch primitiveType {
+	case core.Bool:
+		return o.buildType("NUMBER(1)", col, 0)
+	case core.Int8, core.Uint8:
+		return o.buildType("NUMBER(3)", col, 0)
+	case core.Int16, core.Uint16:
+		return o.buildType("NUMBER(5)", col, 0)
+	case core.Int32, core.Uint32:
+		return o.buildType("NUMBER(10)", col, 0)
+	case core.Int64, core.Int:
+		return o.buildType("NUMBER(19)", col, 0)
+	case core.Uint64, core.Uint:
+		return o.buildType("NUMBER(20)", col, 0)
+	case core.Float32:
+		return o.buildType("BINARY_FLOAT", col, 0)
+	case core.Float64:
+		return o.buildType("BINARY_DOUBLE", col, 0)
+	case core.Decimal:
+		if len(col.Length) != 2 {
+			return "", missLength(col)
+		}
+		return o.buildType("NUMBER", col, 2)
+	case core.String:
+		if len(col.Length) == 0 || col.Length[0] == -1 || col.Length[0] > 4000 {
+			return o.buildType("CLOB", col, 0)
+		}
+		return o.buildType("VARCHAR2", col, 1)
+	case core.Bytes:
+		return o.buildType("BLOB", col, 0)
+	case core.Time:
+		if len(col.Length) == 0 {
+			return o.buildType("TIMESTAMP", col, 0)
+		}
+		if col.Length[0] < 0 || col.Length[0] > 6 {
+			return "", invalidTimeFractional(col)
+		}
+		return o.buildType("TIMESTAMP", col, 1)
+	default:
+		return "", errUncovert(col)
+	}
+}
+
+// l 表示需要取的长度数量
+//
+// oracle 要求 DEFAULT 必须在 NOT NULL 之前。
+func (o *oracle) buildType(typ string, col *core.Column, l int) (string, error) {
+	w := core.NewBuilder(typ)
+
+	switch {
+	case l == 1 && len(col.Length) > 0:
+		w.Quote(strconv.Itoa(col.Length[0]), '(', ')')
+	case l == 2 && len(col.Length) > 1:
+		w.WBytes('(').
+			WString(strconv.Itoa(col.Length[0])).
+			WBytes(',').
+			WString(strconv.Itoa(col.Length[1])).
+			WBytes(')')
+	}
+
+	if col.AI {
+		w.WString(" GENERATED BY DEFAULT AS IDENTITY")
+	}
+
+	if col.HasDefault {
+		v, err := o.formatSQL(col)
+		if err != nil {
+			return "", err
+		}
+		w.WString(" DEFAULT ").WString(v)
+	}
+
+	if !col.Nullable {
+		w.WString(" NOT NULL")
+	}
+
+	return w.String()
+}
+
+func (o *oracle) formatSQL(col *core.Column) (string, error) {
+	if v, found, err := customDefault(o.Name(), col); found {
+		return v, err
+	}
+
+	if t, ok := col.Default.(time.Time); ok {
+		v, err := formatTime(col, t)
+		if err != nil {
+			return "", err
+		}
+		return "TIMESTAMP " + v, nil
+	}
+	return o.Literal(col.Default)
+}
@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package dialect_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/issue9/assert/v4"
+
+	"github.com/issue9/orm/v6/core"
+	"github.com/issue9/orm/v6/dialect"
+	"github.com/issue9/orm/v6/internal/sqltest"
+	"github.com/issue9/orm/v6/sqlbuilder"
+)
+
+func TestOracle_Fix(t *testing.T) {
+	a := assert.New(t, false)
+	d := dialect.Oracle("godror")
+
+	query, args, err := d.Fix("SELECT * FROM tbl WHERE id=? AND name=?", []any{1, "n"})
+	a.NotError(err).Equal(args, []any{1, "n"})
+	sqltest.Equal(a, query, "SELECT * FROM tbl WHERE id=:1 AND name=:2")
+
+	query, args, err = d.Fix("SELECT * FROM tbl WHERE id=@id AND name=@name", []any{sql.Named("name", "n"), sql.Named("id", 1)})
+	a.NotError(err).Equal(args, []any{1, "n"})
+	sqltest.Equal(a, query, "SELECT * FROM tbl WHERE id=:1 AND name=:2")
+
+	query, orders, err := d.Prepare("SELECT * FROM tbl WHERE id=@id AND name=@name")
+	a.NotError(err).Equal(orders, map[string]int{"id": 0, "name": 1})
+	sqltest.Equal(a, query, "SELECT * FROM tbl WHERE id=:1 AND name=:2")
+}
+
+func TestOracle_Literal(t *testing.T) {
+	a := assert.New(t, false)
+	d := dialect.Oracle("godror")
+
+	data := []struct {
+		v   any
+		out string
+	}{
+		{v: nil, out: "NULL"},
+		{v: 5, out: "5"},
+		{v: false, out: "0"},
+		{v: `a'b\c`, out: `'a''b\c'`},
+		{v: []byte{0x0a, 0xff}, out: "HEXTORAW('0aff')"},
+		{v: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), out: "TIMESTAMP '2024-01-02 03:04:05'"},
+	}
+	for _, item := range data {
+		out, err := d.Literal(item.v)
+		a.NotError(err).Equal(out, item.out, "%v", item.v)
+	}
+}
+
+func TestOracle_SQLType(t *testing.T) {
+	a := assert.New(t, false)
+
+	var data = []*sqlTypeTester{
+		{
+			col: &core.Column{PrimitiveType: core.Auto},
+			err: true,
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Bool, HasDefault: true, Default: true},
+			SQLType: "NUMBER(1) DEFAULT 1 NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Int8},
+			SQLType: "NUMBER(3) NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Uint16},
+			SQLType: "NUMBER(5) NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Int32},
+			SQLType: "NUMBER(10) NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Int64, AI: true},
+			SQLType: "NUMBER(19) GENERATED BY DEFAULT AS IDENTITY NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Uint, Nullable: true},
+			SQLType: "NUMBER(20)",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Float32},
+			SQLType: "BINARY_FLOAT NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Float64},
+			SQLType: "BINARY_DOUBLE NOT NULL",
+		},
+		{
+			col: &core.Column{PrimitiveType: core.Decimal, Length: []int{5}},
+			err: true,
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Decimal, Length: []int{10, 2}},
+			SQLType: "NUMBER(10,2) NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.String, Length: []int{100}, HasDefault: true, Default: ""},
+			SQLType: "VARCHAR2(100) DEFAULT '' NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.String},
+			SQLType: "CLOB NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Bytes},
+			SQLType: "BLOB NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Time, Length: []int{6}},
+			SQLType: "TIMESTAMP(6) NOT NULL",
+		},
+		{
+			col: &core.Column{PrimitiveType: core.Time, Length: []int{7}},
+			err: true,
+		},
+	}
+
+	testSQLType(a, dialect.Oracle("godror"), data)
+}
+
+func TestOracle_sqlbuilder(t *testing.T) {
+	a := assert.New(t, false)
+	db := newOfflineDB(dialect.Oracle("godror"))
+	defer func() { a.NotError(db.Close()) }()
+	sb := db.SQLBuilder()
+
+	qs, err := db.RenderDDL(sb.CreateTable().Table("#users").
+		AutoIncrement("id", core.Int64).
+		Column("name", core.String, false, false, true, "n", 20))
+	a.NotError(err).Length(qs, 1)
+	sqltest.Equal(a, qs[0], `BEGIN EXECUTE IMMEDIATE 'CREATE TABLE "p_users"("id" NUMBER(19) GENERATED BY DEFAULT AS IDENTITY NOT NULL,"name" VARCHAR2(20) DEFAULT ''n'' NOT NULL)'; EXCEPTION WHEN OTHERS THEN IF SQLCODE != -955 THEN RAISE; END IF; END;`)
+
+	qs, err = db.RenderDDL(sb.DropTable().Table("#users"))
+	a.NotError(err).Length(qs, 1)
+	sqltest.Equal(a, qs[0], `BEGIN EXECUTE IMMEDIATE 'DROP TABLE "p_users"'; EXCEPTION WHEN OTHERS THEN IF SQLCODE != -942 THEN RAISE; END IF; END;`)
+
+	query, args, err := db.Render(sb.Select().Column("*").From("#users").Where("id>?", 5).Desc("id").Limit(10, 20))
+	a.NotError(err).Equal(args, []any{5, 20, 10})
+	sqltest.Equal(a, query, `SELECT * FROM "p_users" WHERE id>:1 ORDER BY id DESC OFFSET :2 ROWS FETCH NEXT :3 ROWS ONLY`)
+
+	hook, ok := db.Dialect().(sqlbuilder.ReturningIntoHooker)
+	a.True(ok)
+	var id int64
+	q, out := hook.ReturningIntoHook("id", &id)
+	a.Equal(q, " RETURNING {id} INTO ?").Equal(out, sql.Out{Dest: &id})
+
+	qs, err = db.RenderDDL(sb.AddConstraint().Table("#users").FK("users_fk", "gid", "#groups", "id", "", "cascade"))
+	a.NotError(err)
+	sqltest.Equal(a, qs[0], `ALTER TABLE "p_users" ADD CONSTRAINT "users_fk" FOREIGN KEY("gid") REFERENCES "p_groups"("id") ON DELETE CASCADE`)
+
+	_, err = db.RenderDDL(sb.AddConstraint().Table("#users").FK("users_fk", "gid", "#groups", "id", "cascade", ""))
+	a.Error(err)
+
+	qs, err = db.RenderDDL(sb.AddConstraint().Table("#users").Unique("users_u", "name", "id"))
+	a.NotError(err)
+	sqltest.Equal(a, qs[0], `ALTER TABLE "p_users" ADD CONSTRAINT "users_u" UNIQUE("name","id")`)
+
+	qs, err = db.RenderDDL(sb.AlterColumn().Table("#users").Column("name", core.String, false, true, false, nil, 50))
+	a.NotError(err)
+	sqltest.Equal(a, qs[0], `ALTER TABLE "p_users" MODIFY ("name" VARCHAR2(50) DEFAULT NULL NULL)`)
+
+	qs, err = db.RenderDDL(sb.TruncateTable().Table("#users", "id"))
+	a.NotError(err).Equal(qs, []string{
+		`TRUNCATE TABLE "p_users"`,
+		`ALTER TABLE "p_users" MODIFY "id" GENERATED BY DEFAULT AS IDENTITY (START WITH 1)`,
+	})
+
+	qs, err = db.RenderDDL(sb.DropIndex().Table("#users").Name("index_name"))
+	a.NotError(err).Equal(qs, []string{`DROP INDEX "index_name"`})
+
+	_, _, err = db.Render(sb.Delete().Table("#users").With("t", sb.Select().Column("id").From("#users")).Where("id IN (SELECT id FROM t)"))
+	a.Error(err)
+
+	a.False(db.Dialect().TransactionalDDL())
+}
+
+func TestOracle_ScriptFormatter(t *testing.T) {
+	a := assert.New(t, false)
+	f, ok := dialect.Oracle("godror").(core.ScriptFormatter)
+	a.True(ok)
+
+	a.Equal(f.ScriptStatement(`ALTER TABLE "p_users" DROP COLUMN "id"`), `ALTER TABLE "p_users" DROP COLUMN "id";`).
+		Equal(f.ScriptStatement(`BEGIN EXECUTE IMMEDIATE 'DROP TABLE "p_users"'; END;`), `BEGIN EXECUTE IMMEDIATE 'DROP TABLE "p_users"'; END;
+/`)
+
+	begin, commit := f.ScriptTransaction()
+	a.Empty(begin).Equal(commit, "COMMIT;")
+}
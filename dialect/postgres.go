@@ -7,6 +7,7 @@ package dialect
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
@@ -17,17 +18,116 @@ import (
 	"github.com/lib/pq"
 
 	"github.com/issue9/orm/v6/core"
+	"github.com/issue9/orm/v6/sqlbuilder"
 )
 
+// postgres 兼容数据库之间的差异
+type postgresOptions struct {
+	name string
+
+	// 修正 lib/pq 对 time.Time 的处理
+	utcTime bool
+
+	// 自增列由 unique_rowid() 生成
+	//
+	// 生成的值只保证唯一，不保证连续，且只能是 64 位整数。
+	rowID bool
+
+	// TRUNCATE 是否支持 RESTART IDENTITY
+	restartIdentity bool
+
+	// 是否支持咨询锁
+	advisoryLock bool
+
+	// 是否可以通过 pg_dump 备份
+	pgDump bool
+
+	transactionalDDL bool
+
+	// 系统表采用 cockroach 的结构
+	cockroachCatalog bool
+
+	// 支持 CREATE TYPE IF NOT EXISTS 语法
+	//
+	// 不支持的需要通过 DO 语句忽略类型已经存在的错误。
+	createTypeIfNotExists bool
+}
+
 type postgres struct {
 	base
+	opt *postgresOptions
+}
+
+// 支持咨询锁的 postgres
+type postgresLocker struct {
+	*postgres
 }
 
+var (
+	_ sqlbuilder.JoinSyntaxHooker        = &postgres{}
+	_ sqlbuilder.UpdateDeleteLimitHooker = &postgres{}
+	_ core.Introspector                  = &postgres{}
+	_ sqlbuilder.AlterColumnStmtHooker   = &postgres{}
+	_ sqlbuilder.JSONHooker              = &postgres{}
+	_ sqlbuilder.EnumHooker              = &postgres{}
+	_ core.AdvisoryLocker                = &postgresLocker{}
+)
+
 // Postgres 返回一个适配 postgresql 的 [core.Dialect] 接口
+//
+// 适用于 github.com/lib/pq，会修正该驱动对 [time.Time] 的处理。
 func Postgres(driverName string) core.Dialect {
-	return &postgres{
-		base: newBase("postgres", driverName, '"', '"'),
+	return newPostgres(driverName, &postgresOptions{
+		name:             "postgres",
+		utcTime:          true,
+		restartIdentity:  true,
+		advisoryLock:     true,
+		pgDump:           true,
+		transactionalDDL: true,
+	})
+}
+
+// Pgx 返回一个适配 postgresql 的 [core.Dialect] 接口
+//
+// 适用于 github.com/jackc/pgx/v5/stdlib，与 [Postgres] 的区别在于不会对参数作额外的处理。
+func Pgx(driverName string) core.Dialect {
+	return newPostgres(driverName, &postgresOptions{
+		name:             "postgres",
+		restartIdentity:  true,
+		advisoryLock:     true,
+		pgDump:           true,
+		transactionalDDL: true,
+	})
+}
+
+// Cockroach 返回一个适配 cockroachdb 的 [core.Dialect] 接口
+//
+// utcTime 表示是否需要修正 lib/pq 对 [time.Time] 的处理，
+// 推荐使用 github.com/jackc/pgx/v5/stdlib，此时 utcTime 应该为 false。
+//
+// NOTE: 自增列采用 SERIAL8，其值由 unique_rowid() 生成，只保证唯一而不是连续的，
+// 所以自增列只能是 64 位的整数，且 TRUNCATE 之后也不会重新计数。
+// 另外 cockroach 也不支持咨询锁和 Backup 操作。
+func Cockroach(driverName string, utcTime bool) core.Dialect {
+	return newPostgres(driverName, &postgresOptions{
+		name:                  "cockroach",
+		utcTime:               utcTime,
+		rowID:                 true,
+		cockroachCatalog:      true,
+		createTypeIfNotExists: true,
+	})
+}
+
+func newPostgres(driverName string, opt *postgresOptions) core.Dialect {
+	p := &postgres{
+		base: newBase(opt.name, driverName, '"', '"'),
+		opt:  opt,
+	}
+
+	if opt.advisoryLock {
+		return &postgresLocker{postgres: p}
 	}
+	return p
 }
 
 func (p *postgres) VersionSQL() string { return `SHOW server_version;` }
@@ -46,13 +146,33 @@ func (p *postgres) Prepare(query string) (string, map[string]int, error) {
 	return query, orders, nil
 }
 
+// Literal 字符串采用标准 SQL 的转义方式，要求 standard_conforming_strings 处于开启状态。
+func (p *postgres) Literal(v any) (string, error) {
+	return literal(p.Name(), v, func(v driver.Value) (string, bool) {
+		switch vv := v.(type) {
+		case bool:
+			if vv {
+				return "TRUE", true
+			}
+			return "FALSE", true
+		case string:
+			return "'" + quoteApostrophe.Replace(vv) + "'", true
+		case []byte:
+			return `'\x` + hex.EncodeToString(vv) + "'::bytea", true
+		case time.Time:
+			return timeLiteral(vv), true
+		}
+		return "", false
+	})
+}
+
 func (p *postgres) LastInsertIDSQL(table, col string) (sql string, append bool) {
 	return " RETURNING " + col, true
 }
 
 // Fix 在有 ? 占位符的情况下，语句中不能包含 $ 字符串
 func (p *postgres) Fix(query string, args []any) (string, []any, error) {
-	query, args, err := fixQueryAndArgs(query, args)
+	query, args, err := fixQueryAndArgs(p.Name(), query, args)
 	if err != nil {
 		return "", nil, err
 	}
@@ -62,6 +182,10 @@ func (p *postgres) Fix(query string, args []any) (string, []any, error) {
 		return "", nil, err
 	}
 
+	if !p.opt.utcTime {
+		return query, args, nil
+	}
+
 	// lib/pq 对 time.Time 的处理有问题，保存时不会考虑其时区，
 	// 直接从字面值当作零时区进行保存。
 	// https://github.com/lib/pq/issues/329
@@ -113,11 +237,64 @@ func (p *postgres) LimitSQL(limit any, offset ...any) (string, []any) {
 	return mysqlLimitSQL(limit, offset...)
 }
 
+// 类型、是否为空以及默认值需要分别修改，但可以合并在一条语句中。
+func (p *postgres) AlterColumnStmtHook(stmt *sqlbuilder.AlterColumnStmt) ([]string, error) {
+	col := stmt.Col.Clone()
+	col.AI = false // 自增列的类型为 SERIAL，只能用于创建列。
+	col.Nullable = true
+	col.HasDefault = false
+	typ, err := p.SQLType(col)
+	if err != nil {
+		return nil, err
+	}
+
+	alter := func(b *core.Builder) *core.Builder {
+		return b.WString(" ALTER COLUMN ").QuoteKey(stmt.Col.Name).WBytes(' ')
+	}
+
+	b := core.NewBuilder("ALTER TABLE ").QuoteTable(stmt.TableName)
+	alter(b).WString("TYPE ").WString(typ).WBytes(',')
+
+	if stmt.Col.Nullable {
+		alter(b).WString("DROP NOT NULL,")
+	} else {
+		alter(b).WString("SET NOT NULL,")
+	}
+
+	switch {
+	case stmt.Col.AI: // 自增列的默认值为 nextval() 或是 unique_rowid()，不能删除。
+		b.TruncateLast(1)
+	case stmt.Col.HasDefault:
+		v, err := p.formatSQL(stmt.Col)
+		if err != nil {
+			return nil, err
+		}
+		alter(b).WString("SET DEFAULT ").WString(v)
+	default:
+		alter(b).WString("DROP DEFAULT")
+	}
+
+	query, err := b.String()
+	if err != nil {
+		return nil, err
+	}
+	return []string{query}, nil
+}
+
+// postgres 的咨询锁只接受数值，通过 hashtext 将 name 转换成数值。
+func (p *postgresLocker) LockSQL(name string) (string, []any) {
+	return "SELECT pg_advisory_lock(hashtext(?))", []any{name}
+}
+
+func (p *postgresLocker) UnlockSQL(name string) (string, []any) {
+	return "SELECT pg_advisory_unlock(hashtext(?))", []any{name}
+}
+
 func (p *postgres) TruncateTableSQL(table, ai string) ([]string, error) {
 	builder := core.NewBuilder("TRUNCATE TABLE ").
-		QuoteKey(table)
+		QuoteTable(table)
 
-	if ai != "" {
+	if ai != "" && p.opt.restartIdentity {
 		builder.WString(" RESTART IDENTITY")
 	}
 
@@ -146,15 +323,159 @@ func (p *postgres) CreateViewSQL(replace, temporary bool, name, selectQuery stri
 	return []string{q}, nil
 }
 
-func (p *postgres) TransactionalDDL() bool { return true }
+func (p *postgres) TransactionalDDL() bool { return p.opt.transactionalDDL }
 
-func (p *postgres) DropIndexSQL(_, index string) (string, error) { return stdDropIndex(index) }
+func (p *postgres) UpdateJoinSyntax(core.Engine) sqlbuilder.JoinSyntax { return sqlbuilder.JoinFrom }
 
+func (p *postgres) DeleteJoinSyntax(core.Engine) sqlbuilder.JoinSyntax { return sqlbuilder.JoinFrom }
+
+// UpdateDeleteLimitHook cockroach 原生支持，但是没有 ctid 列。
+func (p *postgres) UpdateDeleteLimitHook() (bool, string) {
+	if p.opt.cockroachCatalog {
+		return true, ""
+	}
+	return false, "ctid"
+}
+
+// JSONExtractSQL 采用 -> 和 ->> 操作符，比如 {col}->'a'->>'b'
+func (p *postgres) JSONExtractSQL(col string, path ...string) (string, []any, error) {
+	builder := core.NewBuilder("").QuoteColumn(col)
+	for i, key := range path {
+		if i == len(path)-1 {
+			builder.WString("->>")
+		} else {
+			builder.WString("->")
+		}
+
+		if isJSONIndex(key) {
+			builder.WString(key)
+		} else {
+			builder.Quote(quoteApostrophe.Replace(key), '\'', '\'')
+		}
+	}
+
+	query, err := builder.String()
+	if err != nil {
+		return "", nil, err
+	}
+	return query, nil, nil
+}
+
+func (p *postgres) JSONContainsSQL(col string) (string, error) {
+	return core.NewBuilder("").QuoteColumn(col).WString(" @> ?").String()
+}
+
+// 枚举列对应的类型名称，与表位于同一模式之下。
+func enumTypeName(table, col string) string {
+	name := sqlbuilder.EnumCheckName(table, col)
+	if schema, _, found := strings.Cut(table, "."); found {
+		name = schema + "." + name
+	}
+	return name
+}
+
+// EnumSQLType 会为每个枚举列创建单独的类型，名称为 <table>_<col>_enum。
+func (p *postgres) EnumSQLType(table string, col *core.Column) (string, []string, error) {
+	name := enumTypeName(table, col.Name)
+
+	w := core.NewBuilder("CREATE TYPE ")
+	if p.opt.createTypeIfNotExists {
+		w.WString("IF NOT EXISTS ")
+	}
+	w.QuoteTable(name).WString(" AS ENUM(")
+	if err := sqlbuilder.EnumValues(p, w, col); err != nil {
+		return "", nil, err
+	}
+	w.WBytes(')')
+
+	ddl, err := w.String()
+	if err != nil {
+		return "", nil, err
+	}
+
+	typ, err := core.NewBuilder("").QuoteTable(name).String()
+	if err != nil {
+		return "", nil, err
+	}
+
+	// 类型已经存在时，如果缺少模型中的值，依然返回错误，而不是沿用旧的类型。
+	if !p.opt.createTypeIfNotExists {
+		vals := core.NewBuilder("")
+		if err := sqlbuilder.EnumValues(p, vals, col); err != nil {
+			return "", nil, err
+		}
+		v, err := vals.String()
+		if err != nil {
+			return "", nil, err
+		}
+
+		ddl = "DO $$ BEGIN " + ddl + "; EXCEPTION WHEN duplicate_object THEN " +
+			"IF NOT enum_range(NULL::" + typ + ")::text[] @> ARRAY[" + v + "]::text[] THEN RAISE; END IF; END $$"
+	}
+
+	typ, err = p.buildType(typ, col, 0)
+	return typ, []string{ddl}, err
+}
+
+// DropEnumSQL 删除由 EnumSQLType 创建的类型
+func (p *postgres) DropEnumSQL(table, col string) ([]string, error) {
+	query, err := core.NewBuilder("DROP TYPE IF EXISTS ").QuoteTable(enumTypeName(table, col)).String()
+	if err != nil {
+		return nil, err
+	}
+	return []string{query}, nil
+}
+
+// AlterEnumSQL 只能添加新的值，已经存在的值不会被删除。
+//
+// 在 postgres 12 之前的版本中，ALTER TYPE ... ADD VALUE 无法在事务中执行。
+func (p *postgres) AlterEnumSQL(table string, col *core.Column) ([]string, error) {
+	name := enumTypeName(table, col.Name)
+
+	sqls := make([]string, 0, len(col.Enums))
+	for _, e := range col.Enums {
+		v, err := core.EnumValue(e)
+		if err != nil {
+			return nil, err
+		}
+		l, err := p.Literal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		query, err := core.NewBuilder("ALTER TYPE ").
+			QuoteTable(name).
+			WString(" ADD VALUE IF NOT EXISTS ").
+			WString(l).
+			String()
+		if err != nil {
+			return nil, err
+		}
+		sqls = append(sqls, query)
+	}
+
+	return sqls, nil
+}
+
+// DropIndexSQL 索引与表位于同一模式之下，table 包含模式名时，index 也需要加上该模式。
+func (p *postgres) DropIndexSQL(table, index string) (string, error) {
+	schema, _, found := strings.Cut(table, ".")
+	if !found || index == "" {
+		return stdDropIndex(index)
+	}
+	return core.NewBuilder("DROP INDEX ").QuoteKey(schema).WBytes('.').QuoteKey(index).String()
+}
+
+// ExistsSQL name 可以是 schema.table 的形式，此时返回的 name 字段也包含模式名。
 func (p *postgres) ExistsSQL(name string, view bool) (string, []any) {
 	t := "BASE TABLE"
 	if view {
 		t = "VIEW"
 	}
+
+	if schema, table, found := strings.Cut(name, "."); found {
+		return "SELECT table_schema||'.'||table_name as name FROM information_schema.tables WHERE table_type=? AND table_schema=? AND table_name=?", []any{t, schema, table}
+	}
 	return "SELECT table_name as name FROM information_schema.tables WHERE table_type=? AND table_name=?", []any{t, name}
 }
 
@@ -163,20 +484,34 @@ func (p *postgres) SQLType(col *core.Column) (string, error) {
 		return "", errColIsNil
 	}
 
-	switch col.PrimitiveType {
+	typ, primitiveType := customSQLType(p.Name(), col)
+	if typ != "" {
+		return p.buildType(typ, col, 0)
+	}
+
+	switch primitiveType {
 	case core.Bool:
 		return p.buildType("BOOLEAN", col, 0)
 	case core.Int8, core.Int16, core.Uint8, core.Uint16:
+		if col.AI && p.opt.rowID {
+			return "", errRowIDType(col)
+		}
 		if col.AI {
 			return p.buildType("SERIAL", col, 0)
 		}
 		return p.buildType("SMALLINT", col, 0)
 	case core.Int32, core.Uint32:
+		if col.AI && p.opt.rowID {
+			return "", errRowIDType(col)
+		}
 		if col.AI {
 			return p.buildType("SERIAL", col, 0)
 		}
 		return p.buildType("INT", col, 0)
 	case core.Int64, core.Int, core.Uint64, core.Uint:
+		if col.AI && p.opt.rowID {
+			return p.buildType("SERIAL8", col, 0)
+		}
 		if col.AI {
 			return p.buildType("BIGSERIAL", col, 0)
 		}
@@ -210,6 +545,10 @@ func (p *postgres) SQLType(col *core.Column) (string, error) {
 	}
 }
 
+func errRowIDType(col *core.Column) error {
+	return fmt.Errorf("自增列 %s 只能是 64 位整数", col.Name)
+}
+
 // l 表示需要取的长度数量
 func (p *postgres) buildType(typ string, col *core.Column, l int) (string, error) {
 	w := core.NewBuilder(typ)
@@ -241,6 +580,10 @@ func (p *postgres) buildType(typ string, col *core.Column, l int) (string, error
 }
 
 func (p *postgres) formatSQL(col *core.Column) (f string, err error) {
+	if v, found, err := customDefault(p.Name(), col); found {
+		return v, err
+	}
+
 	v := col.Default
 	if vv, ok := v.(driver.Valuer); ok {
 		v, err = vv.Value()
@@ -249,25 +592,23 @@ func (p *postgres) formatSQL(col *core.Column) (f string, err error) {
 		}
 	}
 
-	if v == nil {
-		return "NULL", nil
-	}
-
 	switch vv := v.(type) {
-	case string:
-		return "'" + quoteApostrophe.Replace(vv) + "'", nil
 	case time.Time: // timestamp
 		return formatTime(col, vv)
 	case sql.NullTime: // timestamp
 		return formatTime(col, vv.Time)
 	}
 
-	return fmt.Sprint(v), nil
+	return p.Literal(v)
 }
 
 func (p *postgres) Backup(dsn, dest string) error {
 	// http://www.postgres.cn/docs/14/app-pgdump.html
 
+	if !p.opt.pgDump {
+		return errors.New(p.Name() + " 不支持 Backup 操作")
+	}
+
 	opt, err := parsePostgresDSN(dsn)
 	if err != nil {
 		return err
@@ -309,3 +650,327 @@ func parsePostgresDSN(dsn string) (opt map[string]string, err error) {
 
 	return opt, nil
 }
+
+func (p *postgres) Tables(e core.Engine) ([]string, error) {
+	return queryStrings(e, "SELECT table_name FROM information_schema.tables WHERE table_schema=current_schema() AND table_type='BASE TABLE' ORDER BY table_name")
+}
+
+// 执行查询表结构的语句
+//
+// query 中每一个 current_schema() 之后都应该跟着一个表示表名的占位符，
+// 如果 table 为 schema.table 的形式，current_schema() 会被替换为指定的模式。
+func (p *postgres) query(e core.Engine, query, table string) (*sql.Rows, error) {
+	cnt := strings.Count(query, "current_schema()")
+	args := make([]any, 0, 2*cnt)
+
+	schema, name, found := strings.Cut(table, ".")
+	if !found {
+		for range cnt {
+			args = append(args, table)
+		}
+		return e.Query(query, args...)
+	}
+
+	for range cnt {
+		args = append(args, schema, name)
+	}
+	return e.Query(strings.ReplaceAll(query, "current_schema()", "?"), args...)
+}
+
+// Introspect table 可以是 schema.table 的形式
+func (p *postgres) Introspect(e core.Engine, table string) (*core.Model, error) {
+	model := core.NewModel(core.Table, table, 10)
+	if schema, name, found := strings.Cut(table, "."); found {
+		model.Schema, model.Name = schema, name
+	}
+
+	if err := p.introspectColumns(e, model, table); err != nil {
+		return nil, err
+	}
+
+	if err := p.introspectConstraints(e, model, table); err != nil {
+		return nil, err
+	}
+
+	if err := p.introspectIndexes(e, model, table); err != nil {
+		return nil, err
+	}
+
+	if err := p.introspectForeignKeys(e, model, table); err != nil {
+		return nil, err
+	}
+
+	if err := p.introspectChecks(e, model, table); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (p *postgres) introspectColumns(e core.Engine, model *core.Model, table string) (err error) {
+	query := `SELECT column_name,data_type,is_nullable,column_default,
+	character_maximum_length,numeric_precision,numeric_scale,datetime_precision
+	FROM information_schema.columns WHERE table_schema=current_schema() AND table_name=?`
+	if p.opt.cockroachCatalog { // 过滤未指定主键时自动添加的 rowid 等隐藏列
+		query += ` AND is_hidden='NO'`
+	}
+	query += ` ORDER BY ordinal_position`
+
+	rows, err := p.query(e, query, table)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, rows.Close()) }()
+
+	ais := make([]*core.Column, 0, 1)
+	for rows.Next() {
+		var (
+			name, dataType, nullable                 string
+			def                                      sql.NullString
+			charLen, precision, scale, timePrecision sql.NullInt64
+		)
+		if err = rows.Scan(&name, &dataType, &nullable, &def, &charLen, &precision, &scale, &timePrecision); err != nil {
+			return err
+		}
+
+		col := &core.Column{Name: name, Nullable: nullable == "YES"}
+		switch dataType {
+		case "boolean":
+			col.PrimitiveType = core.Bool
+		case "smallint":
+			col.PrimitiveType = core.Int16
+		case "integer":
+			col.PrimitiveType = core.Int32
+		case "bigint":
+			col.PrimitiveType = core.Int64
+		case "real":
+			col.PrimitiveType = core.Float32
+		case "double precision":
+			col.PrimitiveType = core.Float64
+		case "numeric":
+			col.PrimitiveType = core.Decimal
+			if precision.Valid {
+				col.Length = []int{int(precision.Int64), int(scale.Int64)}
+			}
+		case "character varying", "character":
+			col.PrimitiveType = core.String
+			col.Length = []int{-1}
+			if charLen.Valid {
+				col.Length[0] = int(charLen.Int64)
+			}
+		case "bytea":
+			col.PrimitiveType = core.Bytes
+		case "date", "time without time zone", "time with time zone",
+			"timestamp without time zone", "timestamp with time zone":
+			col.PrimitiveType = core.Time
+			if timePrecision.Valid && timePrecision.Int64 != 6 { // 6 为默认值
+				col.Length = []int{int(timePrecision.Int64)}
+			}
+		default: // text、json、uuid 等都当作字符串处理
+			col.PrimitiveType = core.String
+			col.Length = []int{-1}
+		}
+
+		if def.Valid {
+			if strings.HasPrefix(def.String, "nextval(") || def.String == "unique_rowid()" { // SERIAL
+				ais = append(ais, col)
+			} else {
+				col.Default, col.HasDefault = parseDefault(def.String)
+			}
+		}
+
+		if err = model.AddColumn(col); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	if len(ais) == 1 { // 存在多个序列时，无法确定哪个是自增列。
+		return model.SetAutoIncrement(ais[0])
+	}
+	return nil
+}
+
+// 主键和唯一约束
+func (p *postgres) introspectConstraints(e core.Engine, model *core.Model, table string) (err error) {
+	const query = `SELECT tc.constraint_name,tc.constraint_type,kcu.column_name
+	FROM information_schema.table_constraints AS tc
+	JOIN information_schema.key_column_usage AS kcu ON kcu.constraint_schema=tc.constraint_schema AND kcu.constraint_name=tc.constraint_name AND kcu.table_name=tc.table_name
+	WHERE tc.table_schema=current_schema() AND tc.table_name=? AND tc.constraint_type IN('PRIMARY KEY','UNIQUE')
+	ORDER BY tc.constraint_name,kcu.ordinal_position`
+
+	rows, err := p.query(e, query, table)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, rows.Close()) }()
+
+	for rows.Next() {
+		var name, typ, colName string
+		if err = rows.Scan(&name, &typ, &colName); err != nil {
+			return err
+		}
+
+		col := model.FindColumn(colName)
+		if col == nil && p.opt.cockroachCatalog && colName == "rowid" { // 隐藏列作为主键
+			continue
+		}
+		if col == nil {
+			return core.ErrColumnNotFound(colName)
+		}
+
+		if typ == "UNIQUE" {
+			err = model.AddUnique(name, col)
+		} else if !col.AI {
+			if err = model.AddPrimaryKey(col); err == nil {
+				model.PrimaryKey.Name = name
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// 不包含由约束自动创建的索引
+func (p *postgres) introspectIndexes(e core.Engine, model *core.Model, table string) (err error) {
+	query := `SELECT i.relname,ix.indisunique,a.attname FROM pg_catalog.pg_index AS ix
+	JOIN pg_catalog.pg_class AS t ON t.oid=ix.indrelid
+	JOIN pg_catalog.pg_class AS i ON i.oid=ix.indexrelid
+	JOIN pg_catalog.pg_namespace AS n ON n.oid=t.relnamespace
+	JOIN pg_catalog.pg_attribute AS a ON a.attrelid=t.oid AND a.attnum=ANY(ix.indkey)
+	WHERE n.nspname=current_schema() AND t.relname=? AND NOT ix.indisprimary
+	AND NOT EXISTS(SELECT 1 FROM pg_catalog.pg_constraint AS c WHERE c.conindid=ix.indexrelid)
+	ORDER BY i.relname,array_position(ix.indkey::int2[],a.attnum)`
+
+	// cockroach 的 pg_index 中不包含约束与索引之间的关联，改由 information_schema.statistics 查询，
+	// 同时需要过滤由主键隐式添加到索引中的列以及 STORING 的列。
+	if p.opt.cockroachCatalog {
+		query = `SELECT index_name,non_unique='NO',column_name FROM information_schema.statistics
+	WHERE table_schema=current_schema() AND table_name=? AND implicit='NO' AND storing='NO'
+	AND index_name NOT IN(SELECT constraint_name FROM information_schema.table_constraints WHERE table_schema=current_schema() AND table_name=?)
+	ORDER BY index_name,seq_in_index`
+	}
+
+	rows, err := p.query(e, query, table)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, rows.Close()) }()
+
+	for rows.Next() {
+		var (
+			name, colName string
+			unique        bool
+		)
+		if err = rows.Scan(&name, &unique, &colName); err != nil {
+			return err
+		}
+
+		col := model.FindColumn(colName)
+		if col == nil {
+			return core.ErrColumnNotFound(colName)
+		}
+
+		typ := core.IndexDefault
+		if unique {
+			typ = core.IndexUnique
+		}
+		if err = model.AddIndex(typ, name, col); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (p *postgres) introspectForeignKeys(e core.Engine, model *core.Model, table string) (err error) {
+	const query = `SELECT c.conname,a.attname,rt.relname,ra.attname,c.confupdtype,c.confdeltype
+	FROM pg_catalog.pg_constraint AS c
+	JOIN pg_catalog.pg_class AS t ON t.oid=c.conrelid
+	JOIN pg_catalog.pg_namespace AS n ON n.oid=t.relnamespace
+	JOIN pg_catalog.pg_class AS rt ON rt.oid=c.confrelid
+	JOIN pg_catalog.pg_attribute AS a ON a.attrelid=c.conrelid AND a.attnum=c.conkey[1]
+	JOIN pg_catalog.pg_attribute AS ra ON ra.attrelid=c.confrelid AND ra.attnum=c.confkey[1]
+	WHERE c.contype='f' AND n.nspname=current_schema() AND t.relname=?
+	ORDER BY c.conname`
+
+	rows, err := p.query(e, query, table)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, rows.Close()) }()
+
+	for rows.Next() {
+		var name, colName, refTable, refCol, update, del string
+		if err = rows.Scan(&name, &colName, &refTable, &refCol, &update, &del); err != nil {
+			return err
+		}
+
+		col := model.FindColumn(colName)
+		if col == nil {
+			return core.ErrColumnNotFound(colName)
+		}
+
+		err = model.NewForeignKey(&core.ForeignKey{
+			Name:         name,
+			Column:       col,
+			RefTableName: refTable,
+			RefColName:   refCol,
+			UpdateRule:   postgresFKRule(update),
+			DeleteRule:   postgresFKRule(del),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (p *postgres) introspectChecks(e core.Engine, model *core.Model, table string) (err error) {
+	const query = `SELECT c.conname,pg_get_constraintdef(c.oid) FROM pg_catalog.pg_constraint AS c
+	JOIN pg_catalog.pg_class AS t ON t.oid=c.conrelid
+	JOIN pg_catalog.pg_namespace AS n ON n.oid=t.relnamespace
+	WHERE c.contype='c' AND n.nspname=current_schema() AND t.relname=?`
+
+	rows, err := p.query(e, query, table)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, rows.Close()) }()
+
+	for rows.Next() {
+		var name, def string
+		if err = rows.Scan(&name, &def); err != nil {
+			return err
+		}
+
+		// def 的格式为 CHECK ((expr))，可能还带 NOT VALID 等后缀。
+		def = strings.TrimSuffix(strings.TrimPrefix(def, "CHECK "), " NOT VALID")
+		if err = model.NewCheck(name, trimParens(def)); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// https://www.postgresql.org/docs/current/catalog-pg-constraint.html
+func postgresFKRule(rule string) string {
+	switch rule {
+	case "c":
+		return "CASCADE"
+	case "n":
+		return "SET NULL"
+	case "d":
+		return "SET DEFAULT"
+	default: // a: NO ACTION, r: RESTRICT
+		return ""
+	}
+}
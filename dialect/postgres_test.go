@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/issue9/assert/v4"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/issue9/orm/v6/internal/sqltest"
 	"github.com/issue9/orm/v6/internal/test"
 	"github.com/issue9/orm/v6/sqlbuilder"
+	"github.com/issue9/orm/v6/types"
 )
 
 func TestPostgres_VersionSQL(t *testing.T) {
@@ -212,6 +214,35 @@ func TestPostgres_SQLType(t *testing.T) {
 	testSQLType(a, dialect.Postgres("postgres_driver"), data)
 }
 
+func TestPostgres_AlterColumnStmtHook(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "", test.Postgres)
+
+	suite.Run(func(t *test.Driver) {
+		db := t.DB
+
+		_, err := db.Exec("CREATE TABLE alter_col(id BIGSERIAL NOT NULL PRIMARY KEY,name VARCHAR(20) NOT NULL)")
+		t.NotError(err)
+		defer func() {
+			_, err = db.Exec("DROP TABLE alter_col")
+			t.NotError(err)
+		}()
+
+		stmt := sqlbuilder.AlterColumn(db).Table("alter_col").Column("name", core.String, false, true, false, nil, 50)
+		qs, err := stmt.DDLSQL()
+		t.NotError(err).Length(qs, 1)
+		sqltest.Equal(a, qs[0], "ALTER TABLE {alter_col} ALTER COLUMN {name} TYPE VARCHAR(50), ALTER COLUMN {name} DROP NOT NULL, ALTER COLUMN {name} DROP DEFAULT")
+		t.NotError(stmt.Exec())
+
+		// 自增列的默认值不能删除
+		stmt = sqlbuilder.AlterColumn(db).Table("alter_col").Column("id", core.Int64, true, false, false, nil)
+		qs, err = stmt.DDLSQL()
+		t.NotError(err).Length(qs, 1)
+		sqltest.Equal(a, qs[0], "ALTER TABLE {alter_col} ALTER COLUMN {id} TYPE BIGINT, ALTER COLUMN {id} SET NOT NULL")
+		t.NotError(stmt.Exec())
+	})
+}
+
 func TestPostgres_TruncateTableSQL(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -230,6 +261,44 @@ func TestPostgres_TruncateTableSQL(t *testing.T) {
 	})
 }
 
+func TestPostgres_LockSQL(t *testing.T) {
+	a := assert.New(t, false)
+	l, ok := dialect.Postgres("postgres_driver_name").(core.AdvisoryLocker)
+	a.True(ok)
+
+	query, args := l.LockSQL("lock")
+	a.Equal(query, "SELECT pg_advisory_lock(hashtext(?))").Equal(args, []any{"lock"})
+
+	query, args = l.UnlockSQL("lock")
+	a.Equal(query, "SELECT pg_advisory_unlock(hashtext(?))").Equal(args, []any{"lock"})
+}
+
+func TestPostgres_Literal(t *testing.T) {
+	a := assert.New(t, false)
+	d := dialect.Postgres("postgres_driver_name")
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	data := []struct {
+		v   any
+		out string
+	}{
+		{v: nil, out: "NULL"},
+		{v: 5, out: "5"},
+		{v: true, out: "TRUE"},
+		{v: false, out: "FALSE"},
+		{v: `a'b\c`, out: `'a''b\c'`},
+		{v: []byte{0x0a, 0xff}, out: `'\x0aff'::bytea`},
+		{v: now, out: "'2024-01-02 03:04:05'"},
+	}
+	for _, item := range data {
+		out, err := d.Literal(item.v)
+		a.NotError(err).Equal(out, item.out, "%v", item.v)
+	}
+
+	out, err := d.Literal(struct{}{})
+	a.Error(err).Empty(out)
+}
+
 func TestPostgres_Fix(t *testing.T) {
 	a := assert.New(t, false)
 	p := dialect.Postgres("driver_name")
@@ -329,3 +398,220 @@ func TestPostgres_Backup(t *testing.T) {
 		d.Assertion.NotError(os.Remove(path))
 	})
 }
+
+func TestPgx(t *testing.T) {
+	a := assert.New(t, false)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("UTC+8", 8*3600))
+
+	p := dialect.Pgx("pgx")
+	a.Equal(p.Name(), "postgres").Equal(p.DriverName(), "pgx")
+	_, ok := p.(core.AdvisoryLocker)
+	a.True(ok).True(p.TransactionalDDL())
+
+	query, args, err := p.Fix("SELECT * FROM tbl WHERE created>?", []any{now})
+	a.NotError(err).Equal(args, []any{now})
+	sqltest.Equal(a, query, "SELECT * FROM tbl WHERE created>$1")
+
+	// lib/pq 会将时间转换成 UTC
+	_, args, err = dialect.Postgres("postgres").Fix("SELECT * FROM tbl WHERE created>?", []any{now})
+	a.NotError(err).Equal(args, []any{now.In(time.UTC)})
+
+	qs, err := p.TruncateTableSQL("tbl", "id")
+	a.NotError(err).Equal(qs, []string{"TRUNCATE TABLE {tbl} RESTART IDENTITY"})
+}
+
+func TestCockroach_SQLType(t *testing.T) {
+	a := assert.New(t, false)
+
+	var data = []*sqlTypeTester{
+		{
+			col:     &core.Column{PrimitiveType: core.Int64, AI: true},
+			SQLType: "SERIAL8 NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Uint, AI: true},
+			SQLType: "SERIAL8 NOT NULL",
+		},
+		{
+			col: &core.Column{PrimitiveType: core.Int32, AI: true},
+			err: true,
+		},
+		{
+			col: &core.Column{PrimitiveType: core.Int8, AI: true},
+			err: true,
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.Int32},
+			SQLType: "INT NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: core.String, Length: []int{20}, HasDefault: true, Default: "a'b"},
+			SQLType: "VARCHAR(20) NOT NULL DEFAULT 'a''b'",
+		},
+	}
+
+	testSQLType(a, dialect.Cockroach("pgx", false), data)
+}
+
+func TestCockroach(t *testing.T) {
+	a := assert.New(t, false)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("UTC+8", 8*3600))
+
+	db := newOfflineDB(dialect.Cockroach("pgx", false))
+	defer func() { a.NotError(db.Close()) }()
+	sb := db.SQLBuilder()
+	d := db.Dialect()
+
+	a.Equal(d.Name(), "cockroach").False(d.TransactionalDDL())
+	_, ok := d.(core.AdvisoryLocker)
+	a.False(ok)
+	a.Error(d.Backup("", ""))
+
+	_, args, err := d.Fix("SELECT * FROM tbl WHERE created>?", []any{now})
+	a.NotError(err).Equal(args, []any{now})
+	_, args, err = dialect.Cockroach("postgres", true).Fix("SELECT * FROM tbl WHERE created>?", []any{now})
+	a.NotError(err).Equal(args, []any{now.In(time.UTC)})
+
+	qs, err := db.RenderDDL(sb.CreateTable().Table("#users").
+		AutoIncrement("id", core.Int64).
+		Column("name", core.String, false, false, false, nil, 20))
+	a.NotError(err).Length(qs, 1)
+	sqltest.Equal(a, qs[0], `CREATE TABLE IF NOT EXISTS "p_users"("id" SERIAL8 NOT NULL,"name" VARCHAR(20) NOT NULL)`)
+
+	qs, err = db.RenderDDL(sb.TruncateTable().Table("#users", "id"))
+	a.NotError(err).Equal(qs, []string{`TRUNCATE TABLE "p_users"`})
+
+	// 原生支持 DELETE ... LIMIT
+	query, args, err := db.Render(sb.Delete().Table("#users").Where("id>?", 5).Desc("id").Limit(10))
+	a.NotError(err).Equal(args, []any{5, 10})
+	sqltest.Equal(a, query, `DELETE FROM "p_users" WHERE id>$1 ORDER BY "id" DESC LIMIT $2`)
+
+	query, _, err = db.Render(sb.Insert().Table("#users").KeyValue("name", "n"))
+	a.NotError(err)
+	sqltest.Equal(a, query, `INSERT INTO "p_users"("name") VALUES($1)`)
+	q, apd := d.LastInsertIDSQL("#users", "id")
+	a.True(apd).Equal(q, " RETURNING id")
+}
+
+func TestPostgres_schema(t *testing.T) {
+	a := assert.New(t, false)
+	p := dialect.Postgres("postgres")
+
+	query, args := p.ExistsSQL("users", false)
+	a.Equal(args, []any{"BASE TABLE", "users"})
+	sqltest.Equal(a, query, "SELECT table_name as name FROM information_schema.tables WHERE table_type=? AND table_name=?")
+
+	query, args = p.ExistsSQL("app.users", true)
+	a.Equal(args, []any{"VIEW", "app", "users"})
+	sqltest.Equal(a, query, "SELECT table_schema||'.'||table_name as name FROM information_schema.tables WHERE table_type=? AND table_schema=? AND table_name=?")
+
+	query, err := p.DropIndexSQL("users", "idx")
+	a.NotError(err).Equal(query, "DROP INDEX {idx}")
+
+	query, err = p.DropIndexSQL("app.users", "idx")
+	a.NotError(err).Equal(query, "DROP INDEX {app}.{idx}")
+
+	qs, err := p.TruncateTableSQL("app.users", "id")
+	a.NotError(err).Equal(qs, []string{"TRUNCATE TABLE {app}.{users} RESTART IDENTITY"})
+}
+
+func TestPostgres_JSON(t *testing.T) {
+	a := assert.New(t, false)
+
+	testSQLType(a, dialect.Postgres("postgres"), []*sqlTypeTester{
+		{
+			col:     &core.Column{PrimitiveType: types.JSONType},
+			SQLType: "JSONB NOT NULL",
+		},
+	})
+
+	db := newOfflineDB(dialect.Postgres("postgres"))
+	defer func() { a.NotError(db.Close()) }()
+	sb := db.SQLBuilder()
+
+	query, args, err := db.Render(sb.Select().
+		JSONColumn("data", "name", "user", "name").
+		From("#users").
+		AndJSON("data", "=", "abc", "tags", "0").
+		AndJSONContains("data", map[string]int{"a": 1}))
+	a.NotError(err).Equal(args, []any{"abc", `{"a":1}`})
+	sqltest.Equal(a, query, `SELECT "data"->'user'->>'name' AS "name" FROM "p_users" WHERE  "data"->'tags'->>0 = $1 AND "data" @> $2`)
+
+	// 键名中的单引号
+	query, args, err = db.Render(sb.Delete().Table("#users").AndJSON("data", "<>", 5, "it's"))
+	a.NotError(err).Equal(args, []any{5})
+	sqltest.Equal(a, query, `DELETE FROM "p_users" WHERE "data"->>'it''s' <> $1`)
+
+	_, _, err = db.Render(sb.Select().JSONColumn("data", "name").From("#users"))
+	a.Error(err)
+}
+
+func TestPostgres_UUID(t *testing.T) {
+	a := assert.New(t, false)
+	p := dialect.Postgres("postgres")
+
+	testSQLType(a, p, []*sqlTypeTester{
+		{
+			col:     &core.Column{PrimitiveType: types.UUIDType},
+			SQLType: "UUID NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: types.TextUUIDType, Nullable: true},
+			SQLType: "UUID",
+		},
+	})
+
+	u, err := types.ParseUUID("0190a6e4-3c5b-7f6e-8a1b-2c3d4e5f6a7b")
+	a.NotError(err)
+
+	_, args, err := p.Fix("SELECT * FROM tbl WHERE id=@id", []any{sql.Named("id", u)})
+	a.NotError(err).Equal(args, []any{u.String()})
+
+	_, args, err = p.Fix("SELECT * FROM tbl WHERE id=? OR id=?", []any{u, (*types.UUID)(nil)})
+	a.NotError(err).Equal(args, []any{u.String(), nil})
+
+	v, err := p.Literal(u)
+	a.NotError(err).Equal(v, "'"+u.String()+"'")
+}
+
+func TestPostgres_Enum(t *testing.T) {
+	a := assert.New(t, false)
+	state := &core.Column{Name: "state", PrimitiveType: core.String, HasDefault: true, Default: "draft", Enums: []any{"draft", "published"}}
+	level := &core.Column{Name: "level", PrimitiveType: core.Int8, Enums: []any{1, 2}}
+
+	db := newOfflineDB(dialect.Postgres("postgres"))
+	defer func() { a.NotError(db.Close()) }()
+	sb := db.SQLBuilder()
+
+	qs, err := db.RenderDDL(sb.CreateTable().Table("#users").Columns(state, level))
+	a.NotError(err).Length(qs, 2)
+	sqltest.Equal(a, qs[0], `DO $$ BEGIN CREATE TYPE "p_users_state_enum" AS ENUM('draft','published'); EXCEPTION WHEN duplicate_object THEN IF NOT enum_range(NULL::"p_users_state_enum")::text[] @> ARRAY['draft','published']::text[] THEN RAISE; END IF; END $$`)
+	sqltest.Equal(a, qs[1], `CREATE TABLE IF NOT EXISTS "p_users"(
+	"state" "p_users_state_enum" NOT NULL DEFAULT 'draft',
+	"level" SMALLINT NOT NULL,
+	CONSTRAINT "p_users_level_enum" CHECK("level" IN(1,2)))`)
+
+	qs, err = db.RenderDDL(sb.AlterEnum().Table("s.#users").Column(state))
+	a.NotError(err).Length(qs, 2)
+	sqltest.Equal(a, qs[0], `ALTER TYPE "s"."p_users_state_enum" ADD VALUE IF NOT EXISTS 'draft'`)
+	sqltest.Equal(a, qs[1], `ALTER TYPE "s"."p_users_state_enum" ADD VALUE IF NOT EXISTS 'published'`)
+
+	qs, err = db.RenderDDL(sb.AlterEnum().Table("#users").Column(level))
+	a.NotError(err).Length(qs, 2)
+	sqltest.Equal(a, qs[0], `ALTER TABLE "p_users" DROP CONSTRAINT "p_users_level_enum"`)
+	sqltest.Equal(a, qs[1], `ALTER TABLE "p_users" ADD CONSTRAINT "p_users_level_enum" CHECK("level" IN(1,2))`)
+
+	_, err = db.RenderDDL(sb.AlterColumn().Table("#users").
+		Column("state", core.String, false, false, false, nil).
+		Enums("draft"))
+	a.Error(err)
+
+	cdb := newOfflineDB(dialect.Cockroach("pgx", false))
+	defer func() { a.NotError(cdb.Close()) }()
+	qs, err = cdb.RenderDDL(cdb.SQLBuilder().AddColumn().Table("#users").
+		Column("state", core.String, false, true, false, nil).
+		Enums("draft", "published"))
+	a.NotError(err).Length(qs, 2)
+	sqltest.Equal(a, qs[0], `CREATE TYPE IF NOT EXISTS "p_users_state_enum" AS ENUM('draft','published')`)
+	sqltest.Equal(a, qs[1], `ALTER TABLE "p_users" ADD "state" "p_users_state_enum"`)
+}
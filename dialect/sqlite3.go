@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -26,9 +27,15 @@ type sqlite3 struct {
 }
 
 var (
+	_ sqlbuilder.AlterColumnStmtHooker    = &sqlite3{}
 	_ sqlbuilder.DropColumnStmtHooker     = &sqlite3{}
 	_ sqlbuilder.DropConstraintStmtHooker = &sqlite3{}
 	_ sqlbuilder.AddConstraintStmtHooker  = &sqlite3{}
+	_ sqlbuilder.AlterEnumStmtHooker      = &sqlite3{}
+	_ sqlbuilder.JoinSyntaxHooker         = &sqlite3{}
+	_ sqlbuilder.UpdateDeleteLimitHooker  = &sqlite3{}
+	_ sqlbuilder.JSONHooker               = &sqlite3{}
+	_ core.Introspector                   = &sqlite3{}
 )
 
 // Sqlite3 返回一个适配 sqlite3 的 [core.Dialect] 接口
@@ -41,7 +48,13 @@ func Sqlite3(driverName string) core.Dialect {
 	}
 }
 
-func (s *sqlite3) Fix(query string, args []any) (string, []any, error) { return query, args, nil }
+// Fix sqlite3 的驱动支持命名参数，仅需要处理 [core.DialectValuer] 类型的参数。
+func (s *sqlite3) Fix(query string, args []any) (string, []any, error) {
+	if err := fixArgs(s.Name(), args); err != nil {
+		return "", nil, err
+	}
+	return query, args, nil
+}
 
 func (s *sqlite3) LastInsertIDSQL(table, col string) (sql string, append bool) { return "", false }
 
@@ -51,6 +64,26 @@ func (s *sqlite3) Prepare(query string) (string, map[string]int, error) {
 	return PrepareNamedArgs(query)
 }
 
+// Literal 布尔值以 1 和 0 表示，兼容不支持 TRUE 和 FALSE 的旧版本。
+func (s *sqlite3) Literal(v any) (string, error) {
+	return literal(s.Name(), v, func(v driver.Value) (string, bool) {
+		switch vv := v.(type) {
+		case bool:
+			if vv {
+				return "1", true
+			}
+			return "0", true
+		case string:
+			return "'" + quoteApostrophe.Replace(vv) + "'", true
+		case []byte:
+			return hexLiteral(vv), true
+		case time.Time:
+			return timeLiteral(vv), true
+		}
+		return "", false
+	})
+}
+
 func (s *sqlite3) CreateTableOptionsSQL(w *core.Builder, options map[string][]string) error {
 	if len(options[sqlite3RowID]) == 1 {
 		val, err := strconv.ParseBool(options[sqlite3RowID][0])
@@ -152,6 +185,55 @@ func (s *sqlite3) DropConstraintStmtHook(stmt *sqlbuilder.DropConstraintStmt) ([
 	return s.buildSQLS(stmt.Engine(), info, stmt.TableName)
 }
 
+// 由于重建表需要从当前的表结构中获取约束信息，
+// 无法通过先删除再添加两条语句完成，所以直接替换原有的约束。
+//
+// https://www.sqlite.org/lang_altertable.html
+// BUG: 可能会让视图失去关联
+func (s *sqlite3) AlterEnumStmtHook(stmt *sqlbuilder.AlterEnumStmt) ([]string, error) {
+	info, err := createtable.ParseSqlite3CreateTable(stmt.TableName, stmt.Engine())
+	if err != nil {
+		return nil, err
+	}
+
+	// 从表结构中解析的约束名已经将 # 替换为表名前缀，
+	// 需要以数据表的实际名称生成约束名才能准确匹配。
+	// 表名前缀依然由 Engine 替换语句中的 # 得到，其余部分则以参数的形式传递。
+	query, table := "SELECT name FROM sqlite_master WHERE `type`='table' AND tbl_name=?", stmt.TableName
+	if strings.HasPrefix(table, "#") {
+		query, table = "SELECT name FROM sqlite_master WHERE `type`='table' AND tbl_name='#'||?", table[1:]
+	}
+	tables, err := queryStrings(stmt.Engine(), query, table)
+	if err != nil {
+		return nil, err
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("表 %s 不存在", stmt.TableName)
+	}
+
+	name := sqlbuilder.EnumCheckName(tables[0], stmt.Col.Name)
+	if c, found := info.Constraints[name]; !found || c.Type != core.ConstraintCheck {
+		// 由 AddColumnStmt 添加的列，约束位于列的定义中，将其从列中移除。
+		col, found := info.Columns[stmt.Col.Name]
+		index := strings.Index(col, " CONSTRAINT "+name+" CHECK")
+		if !found || index < 0 {
+			return nil, fmt.Errorf("不存在的约束:%s", name)
+		}
+		info.Columns[stmt.Col.Name] = col[:index]
+	}
+
+	expr, err := sqlbuilder.EnumCheckExpr(s, stmt.Col)
+	if err != nil {
+		return nil, err
+	}
+	info.Constraints[name] = &createtable.Sqlite3Constraint{
+		Type: core.ConstraintCheck,
+		SQL:  "CONSTRAINT " + name + " CHECK(" + expr + ")",
+	}
+
+	return s.buildSQLS(stmt.Engine(), info, stmt.TableName)
+}
+
 // https://www.sqlite.org/lang_altertable.html
 // BUG: 可能会让视图失去关联
 func (s *sqlite3) DropColumnStmtHook(stmt *sqlbuilder.DropColumnStmt) ([]string, error) {
@@ -169,6 +251,32 @@ func (s *sqlite3) DropColumnStmtHook(stmt *sqlbuilder.DropColumnStmt) ([]string,
 	return s.buildSQLS(stmt.Engine(), info, stmt.TableName)
 }
 
+// https://www.sqlite.org/lang_altertable.html
+// BUG: 可能会让视图失去关联
+func (s *sqlite3) AlterColumnStmtHook(stmt *sqlbuilder.AlterColumnStmt) ([]string, error) {
+	info, err := createtable.ParseSqlite3CreateTable(stmt.TableName, stmt.Engine())
+	if err != nil {
+		return nil, err
+	}
+
+	name := stmt.Col.Name
+	if _, found := info.Columns[name]; !found {
+		return nil, core.ErrColumnNotFound(name)
+	}
+
+	typ, err := s.SQLType(stmt.Col)
+	if err != nil {
+		return nil, err
+	}
+	col, err := core.NewBuilder("").QuoteKey(name).WBytes(' ').WString(typ).String()
+	if err != nil {
+		return nil, err
+	}
+	info.Columns[name] = col
+
+	return s.buildSQLS(stmt.Engine(), info, stmt.TableName)
+}
+
 func (s *sqlite3) buildSQLS(e core.Engine, table *createtable.Sqlite3Table, tableName string) ([]string, error) {
 	ret := make([]string, 0, len(table.Indexes)+1)
 	tmpName := "temp_" + tableName + "_temp"
@@ -181,7 +289,7 @@ func (s *sqlite3) buildSQLS(e core.Engine, table *createtable.Sqlite3Table, tabl
 
 	sel := sqlbuilder.Select(e).From(tableName)
 	for col := range table.Columns {
-		sel.Column(col)
+		sel.Column("{" + col + "}") // 列名可能是关键字
 	}
 
 	query, args, err := sel.Insert().Table(tmpName).SQL()
@@ -267,8 +375,39 @@ func (s *sqlite3) CreateViewSQL(replace, temporary bool, name, selectQuery strin
 	return append(ret, q), nil
 }
 
+// UpdateJoinSyntax UPDATE ... FROM 需要 sqlite3>=3.33
+//
+// 每次都会通过 e 查询版本号，低于该版本或是无法获取版本号时，采用 [sqlbuilder.JoinSubquery]。
+// 不同的 e 可能连接着不同版本的数据库，所以查询结果并不会被缓存。
+func (s *sqlite3) UpdateJoinSyntax(e core.Engine) sqlbuilder.JoinSyntax {
+	if ver, err := sqlbuilder.Version(e); err == nil && versionAtLeast(ver, 3, 33) {
+		return sqlbuilder.JoinFrom
+	}
+	return sqlbuilder.JoinSubquery
+}
+
+// DeleteJoinSyntax sqlite3 的 DELETE 不支持 USING
+func (s *sqlite3) DeleteJoinSyntax(core.Engine) sqlbuilder.JoinSyntax { return sqlbuilder.JoinSubquery }
+
+// UpdateDeleteLimitHook 默认编译的 sqlite3 未开启 SQLITE_ENABLE_UPDATE_DELETE_LIMIT，
+// 统一采用 rowid 改写语句，WITHOUT ROWID 的表需要自行指定主键。
+func (s *sqlite3) UpdateDeleteLimitHook() (bool, string) { return false, "rowid" }
+
 func (s *sqlite3) TransactionalDDL() bool { return true }
 
+func (s *sqlite3) JSONExtractSQL(col string, path ...string) (string, []any, error) {
+	query, err := core.NewBuilder("json_extract(").QuoteColumn(col).WString(", ?)").String()
+	if err != nil {
+		return "", nil, err
+	}
+	return query, []any{jsonPath(path)}, nil
+}
+
+// JSONContainsSQL sqlite3 的 JSON 函数中没有与包含相关的函数
+func (s *sqlite3) JSONContainsSQL(string) (string, error) {
+	return "", errors.New("sqlite3 不支持 JSON 包含查询")
+}
+
 // SQLType 将 col 转换成符合 sqlite3 的类型
 //
 // 具体规则参照:http://www.sqlite.org/datatype3.html
@@ -277,7 +416,12 @@ func (s *sqlite3) SQLType(col *core.Column) (string, error) {
 		return "", errColIsNil
 	}
 
-	switch col.PrimitiveType {
+	typ, primitiveType := customSQLType(s.Name(), col)
+	if typ != "" {
+		return s.buildType(typ, col)
+	}
+
+	switch primitiveType {
 	case core.Bool:
 		return s.buildType("INTEGER", col)
 	case core.String:
@@ -311,7 +455,10 @@ func (s *sqlite3) buildType(typ string, col *core.Column) (string, error) {
 	}
 
 	if col.HasDefault {
-		v, err := s.formatSQL(col.Default)
+		v, found, err := customDefault(s.Name(), col)
+		if !found {
+			v, err = s.formatSQL(col.Default)
+		}
 		if err != nil {
 			return "", err
 		}
@@ -358,3 +505,276 @@ func (s *sqlite3) Backup(dsn, dest string) error {
 
 	return os.WriteFile(dest, data, os.ModePerm)
 }
+
+func (s *sqlite3) Tables(e core.Engine) ([]string, error) {
+	return queryStrings(e, "SELECT name FROM sqlite_master WHERE type='table' AND name NOT LIKE 'sqlite_%' ORDER BY name")
+}
+
+func (s *sqlite3) Introspect(e core.Engine, table string) (*core.Model, error) {
+	m := core.NewModel(core.Table, table, 10)
+
+	var query string
+	if err := e.QueryRow("SELECT sql FROM sqlite_master WHERE type='table' AND name=?", table).Scan(&query); err != nil {
+		return nil, err
+	}
+	ai := strings.Contains(strings.ToUpper(query), "AUTOINCREMENT")
+
+	pks, err := s.introspectColumns(e, m, table, ai)
+	if err != nil {
+		return nil, err
+	}
+
+	// 约束名只能从 CREATE TABLE 语句中获取
+	info, err := createtable.ParseSqlite3CreateTable(table, e)
+	if err != nil {
+		return nil, err
+	}
+	uniques := make(map[string][]string, len(info.Constraints))
+	fks := make(map[string]string, len(info.Constraints))
+	for name, c := range info.Constraints {
+		switch c.Type {
+		case core.ConstraintPK:
+			if m.PrimaryKey != nil {
+				m.PrimaryKey.Name = name
+			}
+		case core.ConstraintUnique:
+			uniques[name] = sqlite3ConstraintColumns(c.SQL)
+		case core.ConstraintFK:
+			if cols := sqlite3ConstraintColumns(c.SQL); len(cols) > 0 {
+				fks[cols[0]] = name
+			}
+		case core.ConstraintCheck:
+			start := strings.IndexByte(c.SQL, '(')
+			if start < 0 {
+				return nil, fmt.Errorf("语法错误:%s", c.SQL)
+			}
+			if err := m.NewCheck(name, trimParens(c.SQL[start:])); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.introspectIndexes(e, m, table, uniques); err != nil {
+		return nil, err
+	}
+
+	if err := s.introspectForeignKeys(e, m, table, fks); err != nil {
+		return nil, err
+	}
+
+	if len(pks) > 1 { // 复合主键的顺序以 table_info 中的 pk 值为准
+		slices.SortFunc(m.PrimaryKey.Columns, func(a, b *core.Column) int { return pks[a.Name] - pks[b.Name] })
+	}
+
+	return m, nil
+}
+
+// 读取列信息，返回主键列及其在主键中的位置。
+func (s *sqlite3) introspectColumns(e core.Engine, m *core.Model, table string, ai bool) (pks map[string]int, err error) {
+	rows, err := e.Query("PRAGMA table_info('" + quoteApostrophe.Replace(table) + "')")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errors.Join(err, rows.Close()) }()
+
+	pks = make(map[string]int, 2)
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, typ        string
+			def              sql.NullString
+		)
+		if err = rows.Scan(&cid, &name, &typ, &notNull, &def, &pk); err != nil {
+			return nil, err
+		}
+
+		col := &core.Column{Name: name, Nullable: notNull == 0}
+		col.PrimitiveType, col.Length = sqlite3Type(typ)
+		if def.Valid {
+			col.Default, col.HasDefault = parseDefault(def.String)
+		}
+
+		if err = m.AddColumn(col); err != nil {
+			return nil, err
+		}
+		if pk > 0 {
+			pks[name] = pk
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, col := range m.Columns {
+		if _, found := pks[col.Name]; !found {
+			continue
+		}
+
+		col.Nullable = false // 主键隐含了 NOT NULL
+
+		// 只有单一的 INTEGER 主键才可以是 AUTOINCREMENT
+		if ai && len(pks) == 1 && col.PrimitiveType == core.Int64 {
+			err = m.SetAutoIncrement(col)
+		} else {
+			err = m.AddPrimaryKey(col)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pks, nil
+}
+
+// uniques 为从 CREATE TABLE 中获取的唯一约束
+func (s *sqlite3) introspectIndexes(e core.Engine, m *core.Model, table string, uniques map[string][]string) (err error) {
+	type index struct {
+		name   string
+		unique bool
+		origin string
+	}
+
+	rows, err := e.Query("PRAGMA index_list('" + quoteApostrophe.Replace(table) + "')")
+	if err != nil {
+		return err
+	}
+
+	indexes := make([]*index, 0, 5)
+	for rows.Next() {
+		var (
+			seq, unique, partial int
+			name, origin         string
+		)
+		if err = rows.Scan(&seq, &name, &unique, &origin, &partial); err != nil {
+			return errors.Join(err, rows.Close())
+		}
+		if origin != "pk" {
+			indexes = append(indexes, &index{name: name, unique: unique == 1, origin: origin})
+		}
+	}
+	if err = errors.Join(rows.Err(), rows.Close()); err != nil {
+		return err
+	}
+
+	slices.SortFunc(indexes, func(a, b *index) int { return strings.Compare(a.name, b.name) })
+	for _, index := range indexes {
+		cols, err := queryStrings(e, "SELECT name FROM pragma_index_info('"+quoteApostrophe.Replace(index.name)+"') ORDER BY seqno")
+		if err != nil {
+			return err
+		}
+
+		name := index.name
+		if index.origin == "u" { // 由 UNIQUE 约束自动生成的索引，名称需要从约束中获取。
+			for n, c := range uniques {
+				if slices.Equal(c, cols) {
+					name = n
+					break
+				}
+			}
+		}
+
+		typ := core.IndexDefault
+		if index.unique {
+			typ = core.IndexUnique
+		}
+		for _, c := range cols {
+			col := m.FindColumn(c)
+			if col == nil {
+				return core.ErrColumnNotFound(c)
+			}
+			if err := m.AddIndex(typ, name, col); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// names 为从 CREATE TABLE 中获取的外键名称，以列名作为键名。
+func (s *sqlite3) introspectForeignKeys(e core.Engine, m *core.Model, table string, names map[string]string) (err error) {
+	rows, err := e.Query("PRAGMA foreign_key_list('" + quoteApostrophe.Replace(table) + "')")
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, rows.Close()) }()
+
+	for rows.Next() {
+		var (
+			id, seq                                   int
+			refTable, from, to, update, del, matching string
+		)
+		if err = rows.Scan(&id, &seq, &refTable, &from, &to, &update, &del, &matching); err != nil {
+			return err
+		}
+		if seq > 0 { // orm 的外键只支持单列
+			continue
+		}
+
+		col := m.FindColumn(from)
+		if col == nil {
+			return core.ErrColumnNotFound(from)
+		}
+
+		name, found := names[from]
+		if !found {
+			name = table + "_fk_" + from
+		}
+
+		err = m.NewForeignKey(&core.ForeignKey{
+			Name:         name,
+			Column:       col,
+			RefTableName: refTable,
+			RefColName:   to,
+			UpdateRule:   fkRule(update),
+			DeleteRule:   fkRule(del),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// 从 CONSTRAINT name UNIQUE(col1,col2) 等约束语句中获取第一个括号中的列
+func sqlite3ConstraintColumns(query string) []string {
+	start := strings.IndexByte(query, '(')
+	end := strings.IndexByte(query, ')')
+	if start < 0 || end < start {
+		return nil
+	}
+
+	cols := strings.Split(query[start+1:end], ",")
+	for i, c := range cols {
+		cols[i] = strings.Trim(strings.TrimSpace(c), "`\"[]")
+	}
+	return cols
+}
+
+// 根据 sqlite3 的类型亲和性规则获取对应的 [core.PrimitiveType]
+//
+// https://www.sqlite.org/datatype3.html
+func sqlite3Type(typ string) (core.PrimitiveType, []int) {
+	name, l := splitType(typ)
+
+	switch {
+	case strings.Contains(name, "INT"):
+		return core.Int64, nil
+	case strings.Contains(name, "CHAR"), strings.Contains(name, "CLOB"), strings.Contains(name, "TEXT"):
+		if len(l) > 0 {
+			return core.String, l[:1]
+		}
+		return core.String, []int{-1}
+	case name == "", strings.Contains(name, "BLOB"):
+		return core.Bytes, nil
+	case strings.Contains(name, "REAL"), strings.Contains(name, "FLOA"), strings.Contains(name, "DOUB"):
+		return core.Float64, nil
+	case strings.Contains(name, "BOOL"):
+		return core.Bool, nil
+	case strings.Contains(name, "DATE"), strings.Contains(name, "TIME"):
+		return core.Time, nil
+	default:
+		return core.Decimal, l
+	}
+}
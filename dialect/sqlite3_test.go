@@ -5,8 +5,12 @@
 package dialect_test
 
 import (
+	"database/sql"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/issue9/assert/v4"
 
@@ -15,6 +19,7 @@ import (
 	"github.com/issue9/orm/v6/internal/sqltest"
 	"github.com/issue9/orm/v6/internal/test"
 	"github.com/issue9/orm/v6/sqlbuilder"
+	"github.com/issue9/orm/v6/types"
 )
 
 // 创建测试数据表的脚本
@@ -61,6 +66,70 @@ func TestSqlite3_VersionSQL(t *testing.T) {
 	})
 }
 
+func TestSqlite3_Literal(t *testing.T) {
+	a := assert.New(t, false)
+	d := dialect.Sqlite3("sqlite3_driver_name")
+
+	data := []struct {
+		v   any
+		out string
+	}{
+		{v: nil, out: "NULL"},
+		{v: int64(-5), out: "-5"},
+		{v: true, out: "1"},
+		{v: false, out: "0"},
+		{v: `a'b\c`, out: `'a''b\c'`},
+		{v: []byte{}, out: "X''"},
+		{v: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), out: "'2024-01-02 03:04:05'"},
+	}
+	for _, item := range data {
+		out, err := d.Literal(item.v)
+		a.NotError(err).Equal(out, item.out, "%v", item.v)
+	}
+}
+
+func TestSqlite3_Introspect(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "", test.Sqlite3)
+
+	suite.Run(func(t *test.Driver) {
+		db := t.DB
+
+		for _, query := range sqlite3CreateTable {
+			_, err := db.Exec(query)
+			t.NotError(err)
+		}
+		defer clearSqlite3CreateTable(t, db)
+
+		i, ok := db.Dialect().(core.Introspector)
+		t.True(ok)
+
+		tables, err := i.Tables(db)
+		t.NotError(err).Equal(tables, []string{"fk_table", "usr"})
+
+		m, err := i.Introspect(db, "usr")
+		t.NotError(err).NotNil(m).
+			Equal(m.Name, "usr").
+			Length(m.Columns, 8).
+			Nil(m.AutoIncrement)
+
+		t.NotNil(m.PrimaryKey).
+			Equal(m.PrimaryKey.Name, "usr_pk").
+			Equal(m.PrimaryKey.Columns[0].Name, "id")
+
+		created := m.FindColumn("created")
+		t.NotNil(created).Equal(created.PrimitiveType, core.Int64).False(created.Nullable)
+
+		t.Length(m.Checks, 1).Equal(m.Checks["xxx"], "created > 0")
+		t.Length(m.ForeignKeys, 1).Equal(m.ForeignKeys[0].RefTableName, "fk_table")
+		t.Length(m.Indexes, 1).Equal(m.Indexes[0].Name, "index_user_mobile")
+		t.Length(m.Uniques, 4)
+
+		_, err = i.Introspect(db, "not_exists")
+		t.Error(err)
+	})
+}
+
 func TestSqlite3_AddConstraintStmtHook(t *testing.T) {
 	a := assert.New(t, false)
 	suite := test.NewSuite(a, "", test.Sqlite3)
@@ -168,6 +237,32 @@ func TestSqlite3_DropColumnStmtHook(t *testing.T) {
 	})
 }
 
+func TestSqlite3_AlterColumnStmtHook(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "", test.Sqlite3)
+
+	suite.Run(func(t *test.Driver) {
+		db := t.DB
+
+		_, err := db.Exec("CREATE TABLE alter_col(id INTEGER NOT NULL,`group` TEXT NOT NULL)")
+		t.NotError(err)
+		defer func() {
+			_, err = db.Exec("DROP TABLE alter_col")
+			t.NotError(err)
+		}()
+
+		// 列名为关键字
+		err = sqlbuilder.AlterColumn(db).
+			Table("alter_col").
+			Column("group", core.String, false, true, false, nil, 20).
+			Exec()
+		t.NotError(err)
+
+		_, err = db.Exec("INSERT INTO alter_col(id) VALUES(1)")
+		t.NotError(err)
+	})
+}
+
 func TestSqlite3_CreateTableOptions(t *testing.T) {
 	a := assert.New(t, false)
 	builder := core.NewBuilder("")
@@ -340,3 +435,93 @@ func TestSqlite3_Backup(t *testing.T) {
 		d.Assertion.NotError(os.Remove(path))
 	})
 }
+
+func TestSqlite3_UpdateJoinSyntax(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "", test.Sqlite3)
+
+	suite.Run(func(t *test.Driver) {
+		ver, err := sqlbuilder.Version(t.DB)
+		t.NotError(err)
+
+		want := sqlbuilder.JoinSubquery
+		if parts := strings.Split(ver, "."); len(parts) > 1 {
+			if minor, err := strconv.Atoi(parts[1]); err == nil && parts[0] == "3" && minor >= 33 {
+				want = sqlbuilder.JoinFrom
+			}
+		}
+		hook, ok := t.DB.Dialect().(sqlbuilder.JoinSyntaxHooker)
+		t.True(ok).
+			Equal(hook.UpdateJoinSyntax(t.DB), want).
+			Equal(hook.DeleteJoinSyntax(t.DB), sqlbuilder.JoinSubquery)
+	})
+}
+
+func TestSqlite3_JSON(t *testing.T) {
+	a := assert.New(t, false)
+
+	testSQLType(a, dialect.Sqlite3("sqlite3"), []*sqlTypeTester{
+		{
+			col:     &core.Column{PrimitiveType: types.JSONType},
+			SQLType: "TEXT NOT NULL",
+		},
+	})
+
+	db := newOfflineDB(dialect.Sqlite3("sqlite3"))
+	defer func() { a.NotError(db.Close()) }()
+	sb := db.SQLBuilder()
+
+	query, args, err := db.Render(sb.Select().
+		JSONColumn("u.data", "name", "user", "name").
+		From("#users", "u").
+		AndJSON("u.data", "=", "abc", "tags", "0"))
+	a.NotError(err).Equal(args, []any{`$."user"."name"`, `$."tags"[0]`, "abc"})
+	sqltest.Equal(a, query, "SELECT json_extract(`u`.`data`, ?) AS `name` FROM `p_users` AS `u` WHERE  json_extract(`u`.`data`, ?) = ?")
+
+	_, _, err = db.Render(sb.Select().Column("*").From("#users").AndJSONContains("data", 1))
+	a.Error(err)
+}
+
+func TestSqlite3_UUID(t *testing.T) {
+	a := assert.New(t, false)
+	s := dialect.Sqlite3("sqlite3")
+
+	testSQLType(a, s, []*sqlTypeTester{
+		{
+			col:     &core.Column{PrimitiveType: types.UUIDType},
+			SQLType: "BLOB NOT NULL",
+		},
+		{
+			col:     &core.Column{PrimitiveType: types.TextUUIDType},
+			SQLType: "TEXT NOT NULL",
+		},
+	})
+
+	u, err := types.ParseUUID("0190a6e4-3c5b-7f6e-8a1b-2c3d4e5f6a7b")
+	a.NotError(err)
+
+	_, args, err := s.Fix("SELECT * FROM tbl WHERE id=@id", []any{sql.Named("id", u)})
+	a.NotError(err).Equal(args, []any{sql.Named("id", u[:])})
+
+	_, args, err = s.Fix("SELECT * FROM tbl WHERE id=?", []any{types.TextUUID(u)})
+	a.NotError(err).Equal(args, []any{types.TextUUID(u)})
+}
+
+func TestSqlite3_Enum(t *testing.T) {
+	a := assert.New(t, false)
+	state := &core.Column{Name: "state", PrimitiveType: core.String, Nullable: true, Enums: []any{"draft", "published"}}
+
+	db := newOfflineDB(dialect.Sqlite3("sqlite3"))
+	defer func() { a.NotError(db.Close()) }()
+
+	qs, err := db.RenderDDL(db.SQLBuilder().CreateTable().Table("#users").Columns(state))
+	a.NotError(err).Length(qs, 1)
+	sqltest.Equal(a, qs[0], "CREATE TABLE IF NOT EXISTS `p_users`(`state` TEXT,CONSTRAINT `p_users_state_enum` CHECK(`state` IN('draft','published')))")
+
+	// 不支持原生类型，AddColumn 同样会生成约束。
+	qs, err = db.RenderDDL(db.SQLBuilder().AddColumn().Table("#users").
+		Column("state", core.String, false, true, false, nil).
+		Enums("draft", "published"))
+	a.NotError(err).Length(qs, 1)
+	sqltest.Equal(a, qs[0], "ALTER TABLE `p_users` ADD `state` TEXT CONSTRAINT `p_users_state_enum` CHECK(`state` IN('draft','published'))")
+}
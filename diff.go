@@ -0,0 +1,321 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package orm
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/issue9/orm/v6/core"
+	"github.com/issue9/orm/v6/sqlbuilder"
+)
+
+// 目前支持的 [ChangeType] 类型
+const (
+	CreateTable    ChangeType = iota + 1 // 数据表不存在，需要创建
+	AddColumn                            // 添加列
+	DropColumn                           // 删除列
+	AddConstraint                        // 添加约束，包括主键、唯一约束、外键和 check 约束
+	DropConstraint                       // 删除约束，包括唯一约束、外键和 check 约束
+	DropPK                               // 删除主键约束
+	AddIndex                             // 添加索引
+	DropIndex                            // 删除索引
+)
+
+type (
+	// ChangeType 表示 [Change] 的类型
+	//
+	// NOTE: [DB.Diff] 并不会比较列的类型、是否可为空以及默认值，
+	// 所以不存在修改列的操作，这类变更需要手动调用 [Upgrader.AlterColumn]。
+	ChangeType int8
+
+	// Change 模型与线上数据表之间的差异
+	Change struct {
+		Type ChangeType
+
+		// 数据表名称
+		//
+		// 即 [TableNamer.TableName] 加上表名前缀之后的值。
+		Table string
+
+		// 列、约束或是索引的名称
+		//
+		// 添加类的操作为模型中的名称，可以直接传递给 [Upgrader] 的相关方法；
+		// 删除类的操作为数据表中的实际名称，需要通过 [Upgrader.Changes] 执行。
+		// 当 Type 为 [CreateTable] 时，该值为空。
+		Name string
+	}
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case CreateTable:
+		return "CREATE TABLE"
+	case AddColumn:
+		return "ADD COLUMN"
+	case DropColumn:
+		return "DROP COLUMN"
+	case AddConstraint:
+		return "ADD CONSTRAINT"
+	case DropConstraint:
+		return "DROP CONSTRAINT"
+	case DropPK:
+		return "DROP PRIMARY KEY"
+	case AddIndex:
+		return "ADD INDEX"
+	case DropIndex:
+		return "DROP INDEX"
+	default:
+		return "<unknown>"
+	}
+}
+
+// Destructive 该操作是否会造成数据的丢失
+func (c *Change) Destructive() bool { return c.Type == DropColumn }
+
+func (c *Change) String() string {
+	if c.Name == "" {
+		return c.Type.String() + " " + c.Table
+	}
+	return c.Type.String() + " " + c.Table + "." + c.Name
+}
+
+// Diff 比较模型与线上数据表之间的差异
+//
+// 只比较列、约束和索引是否存在以及约束和索引所包含的列，
+// 并不会比较列的类型、是否可为空以及默认值，原因可参考 [Upgrader] 的说明。
+// check 约束只比较名称，因为各数据库都会对表达式进行格式化。
+// 视图会被忽略。
+//
+// 返回的值按先删除后添加的顺序排列。
+// 需要当前数据库实现了 [core.Introspector] 接口。
+func (db *DB) Diff(v ...TableNamer) ([]*Change, error) {
+	changes := make([]*Change, 0, 10)
+	for _, obj := range v {
+		c, err := db.diff(obj)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c...)
+	}
+	return changes, nil
+}
+
+// AutoMigrate 根据 [DB.Diff] 的结果更新数据表
+//
+// 数据表不存在时会调用 [DB.Create] 创建，其它操作则由 [Upgrader] 完成。
+// 如果存在会造成数据丢失的操作，比如删除列，需要将 allowDestructive
+// 设置为 true，否则返回错误且不会执行任何操作。
+func (db *DB) AutoMigrate(allowDestructive bool, v ...TableNamer) error {
+	changes := make([][]*Change, 0, len(v))
+	for _, obj := range v {
+		c, err := db.diff(obj)
+		if err != nil {
+			return err
+		}
+
+		if !allowDestructive {
+			for _, cc := range c {
+				if cc.Destructive() {
+					return fmt.Errorf("%s 会造成数据丢失，需要指定 allowDestructive 参数", cc)
+				}
+			}
+		}
+
+		changes = append(changes, c)
+	}
+
+	for i, obj := range v {
+		c := changes[i]
+		switch {
+		case len(c) == 0:
+			continue
+		case c[0].Type == CreateTable:
+			if err := db.Create(obj); err != nil {
+				return err
+			}
+			continue
+		}
+
+		u, err := db.Upgrade(obj)
+		if err != nil {
+			return err
+		}
+		if err := u.Changes(c...).Do(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) diff(v TableNamer) ([]*Change, error) {
+	i, ok := db.Dialect().(core.Introspector)
+	if !ok {
+		return nil, fmt.Errorf("数据库 %s 未实现 core.Introspector 接口", db.Dialect().Name())
+	}
+
+	m, err := db.newModel(v)
+	if err != nil {
+		return nil, err
+	}
+	if m.Type == core.View {
+		return nil, nil
+	}
+
+	name := func(n string) string { return strings.ReplaceAll(n, "#", db.TablePrefix()) }
+	table := name(m.FullName())
+
+	exists, err := db.tableExists(i, m.Schema, table)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return []*Change{{Type: CreateTable, Table: table}}, nil
+	}
+
+	live, err := i.Introspect(db, table)
+	if err != nil {
+		return nil, err
+	}
+
+	var drops, adds []*Change
+	drop := func(typ ChangeType, n string) { drops = append(drops, &Change{Type: typ, Table: table, Name: n}) }
+	add := func(typ ChangeType, n string) { adds = append(adds, &Change{Type: typ, Table: table, Name: n}) }
+
+	// 约束
+
+	wantChecks := make(map[string]bool, len(m.Checks))
+	for _, n := range slices.Sorted(maps.Keys(m.Checks)) {
+		wantChecks[name(constraintName(m.Name, n))] = true
+		if _, found := live.Checks[name(constraintName(m.Name, n))]; !found {
+			add(AddConstraint, n)
+		}
+	}
+	for _, col := range m.Columns { // 由枚举列生成的 CHECK 约束
+		if len(col.Enums) > 0 && !sqlbuilder.IsNativeEnum(db.Dialect(), col) {
+			wantChecks[name(sqlbuilder.EnumCheckName(m.Name, col.Name))] = true
+		}
+	}
+	for _, n := range slices.Sorted(maps.Keys(live.Checks)) {
+		if !wantChecks[n] {
+			drop(DropConstraint, n)
+		}
+	}
+
+	wantFKs := make(map[string]bool, len(m.ForeignKeys))
+	for _, fk := range m.ForeignKeys {
+		n := name(constraintName(m.Name, fk.Name))
+		l, found := live.ForeignKey(n)
+		switch {
+		case !found:
+			add(AddConstraint, fk.Name)
+		case l.Column.Name != fk.Column.Name || l.RefTableName != name(fk.RefTableName) || l.RefColName != fk.RefColName ||
+			!sameRule(l.UpdateRule, fk.UpdateRule) || !sameRule(l.DeleteRule, fk.DeleteRule):
+			drop(DropConstraint, n)
+			add(AddConstraint, fk.Name)
+		}
+		wantFKs[n] = true
+	}
+	for _, fk := range live.ForeignKeys {
+		if !wantFKs[fk.Name] {
+			drop(DropConstraint, fk.Name)
+		}
+	}
+
+	diffConstraints(m.Uniques, live.Uniques, m.Name, name, func(n string) { drop(DropConstraint, n) }, func(n string) { add(AddConstraint, n) })
+
+	if m.AutoIncrement == nil {
+		switch {
+		case m.PrimaryKey == nil && live.PrimaryKey != nil:
+			drop(DropPK, live.PrimaryKey.Name)
+		case m.PrimaryKey != nil && live.PrimaryKey == nil:
+			add(AddConstraint, m.PrimaryKey.Name)
+		case m.PrimaryKey != nil && !sameColumns(m.PrimaryKey.Columns, live.PrimaryKey.Columns):
+			drop(DropPK, live.PrimaryKey.Name)
+			add(AddConstraint, m.PrimaryKey.Name)
+		}
+	}
+
+	diffConstraints(m.Indexes, live.Indexes, m.Name, name, func(n string) { drop(DropIndex, n) }, func(n string) { add(AddIndex, n) })
+
+	// 列
+
+	cols := make([]*Change, 0, len(m.Columns))
+	for _, col := range m.Columns {
+		if live.FindColumn(col.Name) == nil {
+			cols = append(cols, &Change{Type: AddColumn, Table: table, Name: col.Name})
+		}
+	}
+	for _, col := range live.Columns {
+		if m.FindColumn(col.Name) == nil {
+			drop(DropColumn, col.Name)
+		}
+	}
+
+	// 先删除约束和列，再添加列，最后添加约束和索引。
+	changes := make([]*Change, 0, len(drops)+len(cols)+len(adds))
+	changes = append(changes, drops...)
+	changes = append(changes, cols...)
+	return append(changes, adds...), nil
+}
+
+// [core.Introspector.Tables] 仅返回默认模式下的表，指定了模式的表改由 [sqlbuilder.TableExistsStmt] 判断。
+func (db *DB) tableExists(i core.Introspector, schema, table string) (bool, error) {
+	if schema != "" {
+		return db.SQLBuilder().TableExists().Table(table).Exists()
+	}
+
+	tables, err := i.Tables(db)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(tables, table), nil
+}
+
+// 比较唯一约束或是索引
+//
+// 名称相同但列不同的，会先删除再添加。
+func diffConstraints(want, live []*core.Constraint, table string, name func(string) string, drop, add func(string)) {
+	names := make(map[string]bool, len(want))
+	for _, c := range want {
+		n := name(constraintName(table, c.Name))
+		names[n] = true
+
+		index := slices.IndexFunc(live, func(l *core.Constraint) bool { return l.Name == n })
+		switch {
+		case index < 0:
+			add(c.Name)
+		case !sameColumns(c.Columns, live[index].Columns):
+			drop(n)
+			add(c.Name)
+		}
+	}
+
+	for _, c := range live {
+		if !names[c.Name] {
+			drop(c.Name)
+		}
+	}
+}
+
+func sameColumns(c1, c2 []*core.Column) bool {
+	return slices.EqualFunc(c1, c2, func(a, b *core.Column) bool { return a.Name == b.Name })
+}
+
+// 外键的规则是否相同，NO ACTION 和 RESTRICT 均被视为默认值。
+func sameRule(r1, r2 string) bool {
+	rule := func(r string) string {
+		switch r = strings.ToUpper(strings.TrimSpace(r)); r {
+		case "NO ACTION", "RESTRICT":
+			return ""
+		default:
+			return r
+		}
+	}
+	return rule(r1) == rule(r2)
+}
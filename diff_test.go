@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package orm_test
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v4"
+
+	"github.com/issue9/orm/v6"
+	"github.com/issue9/orm/v6/core"
+	"github.com/issue9/orm/v6/internal/test"
+)
+
+func TestDB_Diff(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		changes, err := t.DB.Diff(&u2{})
+		t.NotError(err).Length(changes, 1).
+			Equal(changes[0].Type, orm.CreateTable).
+			Equal(changes[0].Table, t.DB.TablePrefix()+"upgrades")
+
+		sql := t.DB.SQLBuilder().CreateTable().
+			Column("id", core.Int64, false, false, false, nil).
+			Column("name", core.String, false, false, false, nil, 50).
+			Column("username", core.String, false, false, false, nil, 50).
+			Column("created", core.Int64, false, false, false, nil).
+			Column("deleted", core.Int64, false, true, false, nil).
+			Index(core.IndexDefault, "i_name", "name").
+			Unique("u_username", "username").
+			Check("chk_id", "id>0").
+			Table((&u2{}).TableName())
+		t.NotError(sql.Exec())
+		defer func() {
+			t.NotError(t.DB.Drop(&u2{}))
+		}()
+
+		changes, err = t.DB.Diff(&u2{})
+		t.NotError(err)
+		types := make(map[orm.ChangeType][]string, len(changes))
+		for _, c := range changes {
+			types[c.Type] = append(types[c.Type], c.Name)
+		}
+		t.Equal(types[orm.DropConstraint], []string{"chk_id", "u_username"}).
+			Equal(types[orm.DropIndex], []string{"i_name"}).
+			Equal(types[orm.DropColumn], []string{"deleted"}).
+			Equal(types[orm.AddColumn], []string{"modified"}).
+			Equal(types[orm.AddConstraint], []string{"chk_username", "u_id", "_pk"}).
+			Equal(types[orm.AddIndex], []string{"index_name"})
+		t.True(changes[len(changes)-1].Type == orm.AddIndex)
+
+		t.ErrorString(t.DB.AutoMigrate(false, &u2{}), "deleted")
+		changes, err = t.DB.Diff(&u2{})
+		t.NotError(err).Length(changes, 9) // 未执行任何操作
+
+		t.NotError(t.DB.AutoMigrate(true, &u2{}))
+		changes, err = t.DB.Diff(&u2{})
+		t.NotError(err).Empty(changes)
+	})
+}
+
+func TestDB_Diff_enum(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		t.NotError(t.DB.Create(&u3{}))
+		defer func() {
+			t.NotError(t.DB.Drop(&u3{}))
+		}()
+
+		// 枚举生成的 CHECK 约束不应该被删除
+		changes, err := t.DB.Diff(&u3{})
+		t.NotError(err).Empty(changes)
+	})
+}
@@ -113,7 +113,7 @@ func parseObject(v reflect.Value, ret *map[string]reflect.Value) error {
 
 		vf2 := getRealValue(vf)
 
-		if core.GetPrimitiveType(vf.Type()) == core.Auto {
+		if core.GetPrimitiveType(vf2.Type()) == core.Auto { // 指针需要以其指向的类型判断
 			items := make(map[string]reflect.Value, vf2.NumField())
 			if err := parseObject(vf2, &items); err != nil {
 				return err
@@ -205,6 +205,9 @@ func fetchOnceObj(strict bool, val reflect.Value, rows *sql.Rows) (int, error) {
 	if err := rows.Scan(buff...); err != nil {
 		return 0, err
 	}
+	if err := checkEnums(cols, buff); err != nil {
+		return 0, err
+	}
 
 	if err = afterFetch(val); err != nil {
 		return 0, err
@@ -235,6 +238,9 @@ func fetchOnceObjNoStrict(val reflect.Value, rows *sql.Rows) (int, error) {
 		if err = conv.Value(v, item); err != nil {
 			return 0, convertError(index, err)
 		}
+		if err = checkNullableEnum(v, item); err != nil {
+			return 0, convertError(index, err)
+		}
 	}
 
 	if err = afterFetch(val); err != nil {
@@ -275,6 +281,9 @@ func fetchObjToFixedSlice(strict bool, val reflect.Value, rows *sql.Rows) (int,
 		if err := rows.Scan(buff...); err != nil {
 			return 0, err
 		}
+		if err := checkEnums(cols, buff); err != nil {
+			return 0, err
+		}
 
 		if err = afterFetch(val.Index(i)); err != nil {
 			return 0, err
@@ -313,6 +322,9 @@ func fetchObjToFixedSliceNoStrict(val reflect.Value, rows *sql.Rows) (int, error
 			if err = conv.Value(v, item); err != nil {
 				return i, convertError(index, err) // 已经有 i 条数据被正确导出
 			}
+			if err = checkNullableEnum(v, item); err != nil {
+				return i, convertError(index, err)
+			}
 		} // end for objItem
 
 		if err = afterFetch(val.Index(i)); err != nil {
@@ -365,6 +377,9 @@ func fetchObjToSlice(strict bool, val reflect.Value, rows *sql.Rows) (int, error
 		if err := rows.Scan(buff...); err != nil {
 			return 0, err
 		}
+		if err := checkEnums(cols, buff); err != nil {
+			return 0, err
+		}
 
 		if err = afterFetch(elem.Index(i)); err != nil {
 			return 0, err
@@ -414,6 +429,9 @@ func fetchObjToSliceNoStrict(val reflect.Value, rows *sql.Rows) (int, error) {
 			if err = conv.Value(e, item); err != nil {
 				return i, convertError(index, err)
 			}
+			if err = checkNullableEnum(e, item); err != nil {
+				return i, convertError(index, err)
+			}
 		} // end for objItem
 
 		if err = afterFetch(elem.Index(i)); err != nil {
@@ -424,6 +442,44 @@ func fetchObjToSliceNoStrict(val reflect.Value, rows *sql.Rows) (int, error) {
 	return len(mapped), nil
 }
 
+// 检测 buff 中枚举类型的值是否在其取值范围内
+//
+// buff 为 getColumns 的返回值。
+func checkEnums(cols []string, buff []any) error {
+	for i, b := range buff {
+		if err := checkEnum(reflect.ValueOf(b).Elem()); err != nil {
+			return convertError(cols[i], err)
+		}
+	}
+	return nil
+}
+
+func checkEnum(v reflect.Value) error {
+	for v.Kind() == reflect.Ptr { // 可为空的字段，nil 表示 NULL
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if v.CanAddr() { // Enums 可能是指针接收者
+		v = v.Addr()
+	}
+
+	if e, ok := v.Interface().(core.Enumer); ok {
+		return core.CheckEnum(e)
+	}
+	return nil
+}
+
+// 非严格模式下 NULL 会被转换成空值，不需要检测。
+func checkNullableEnum(src any, v reflect.Value) error {
+	if src == nil {
+		return nil
+	}
+	return checkEnum(v)
+}
+
 func afterFetch(v reflect.Value) error {
 	if f, ok := v.Interface().(AfterFetcher); ok {
 		return f.AfterFetch()
@@ -424,3 +424,65 @@ func TestObjectNotFound(t *testing.T) {
 		t.NotError(rows.Close())
 	})
 }
+
+type enumName string
+
+func (n *enumName) Enums() []any { return []any{enumName("username-1"), enumName("username-2")} }
+
+func TestObject_enum(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	type user struct {
+		ID       int       `orm:"name(id)"`
+		Username enumName  `orm:"name(username)"`
+		Email    *enumName `orm:"name(email);nullable"`
+	}
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		for _, strict := range []bool{true, false} {
+			rows, err := t.DB.Query(`SELECT id,username FROM fetch_users WHERE id<3 ORDER BY id`)
+			t.NotError(err).NotNil(rows)
+			objs := []*user{}
+			cnt, err := fetch.Object(strict, rows, &objs)
+			t.NotError(err).Equal(cnt, 2).
+				Equal(objs[1].Username, enumName("username-2"))
+			t.NotError(rows.Close())
+
+			// 不在枚举值中
+			rows, err = t.DB.Query(`SELECT id,username FROM fetch_users WHERE id=3`)
+			t.NotError(err).NotNil(rows)
+			obj := &user{}
+			_, err = fetch.Object(strict, rows, obj)
+			t.Error(err)
+			t.NotError(rows.Close())
+
+			// 可为空的枚举
+			rows, err = t.DB.Query(`SELECT id,username,email FROM fetch_users WHERE id=1`)
+			t.NotError(err).NotNil(rows)
+			obj = &user{}
+			_, err = fetch.Object(strict, rows, obj)
+			t.Error(err)
+			t.NotError(rows.Close())
+
+			rows, err = t.DB.Query(`SELECT id,username,username AS email FROM fetch_users WHERE id=1`)
+			t.NotError(err).NotNil(rows)
+			obj = &user{}
+			_, err = fetch.Object(strict, rows, obj)
+			t.NotError(err).Equal(*obj.Email, enumName("username-1"))
+			t.NotError(rows.Close())
+
+			if !strict { // 严格模式下 NULL 无法转换
+				rows, err = t.DB.Query(`SELECT id,username,NULL AS email FROM fetch_users WHERE id=1`)
+				t.NotError(err).NotNil(rows)
+				obj = &user{}
+				_, err = fetch.Object(strict, rows, obj)
+				t.NotError(err)
+				t.NotError(rows.Close())
+			}
+		}
+	})
+}
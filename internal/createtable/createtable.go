@@ -14,7 +14,10 @@ import (
 	"github.com/issue9/orm/v6/core"
 )
 
-var backQuoteReplacer = strings.NewReplacer("`", "")
+// 去掉标识符的引号
+//
+// sqlite 在重命名列之后，会将 create table 中的列名改为以双引号的形式表示。
+var backQuoteReplacer = strings.NewReplacer("`", "", `"`, "")
 
 func lines(sql string) []string {
 	sql = backQuoteReplacer.Replace(sql)
@@ -29,4 +29,10 @@ func TestLines(t *testing.T) {
 		"name string",
 		"unique fk(id,name)",
 	})
+
+	query = "create table `tb1`(`id` int,\"name\" string)"
+	a.Equal(lines(query), []string{
+		"id int",
+		"name string",
+	})
 }
@@ -8,12 +8,14 @@ import (
 	"database/sql"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/issue9/conv"
 
 	"github.com/issue9/orm/v6/core"
 	"github.com/issue9/orm/v6/internal/tags"
+	"github.com/issue9/orm/v6/types"
 )
 
 type Column struct {
@@ -34,6 +36,12 @@ func NewColumn(field reflect.StructField) (*Column, error) {
 
 	col.Name = field.Name
 	col.GoName = field.Name
+
+	v := reflect.New(t)
+	if e, ok := v.Interface().(core.Enumer); ok {
+		col.Enums = e.Enums()
+	}
+
 	return &Column{
 		Column: col,
 		GoType: t,
@@ -71,6 +79,8 @@ func (col *Column) ParseTags(m *core.Model, tag string) (err error) {
 			err = col.SetDefault(tag.Args)
 		case "occ":
 			err = SetOCC(m, col, tag.Args)
+		case "uuid":
+			err = col.SetUUID(tag.Args)
 		default:
 			err = propertyError(col.Name, tag.Name, "未知的属性")
 		}
@@ -165,6 +175,19 @@ func (col *Column) SetDefault(vals []string) error {
 			return err
 		}
 		col.Default = rval.Interface()
+
+		// 枚举需要转换成基础类型，否则 Dialect 无法正确输出其字面量。
+		if e, ok := rval.Addr().Interface().(core.Enumer); ok {
+			if err := core.CheckEnum(e); err != nil {
+				return propertyError(col.Name, "default", err.Error())
+			}
+
+			v, err := core.EnumValue(e)
+			if err != nil {
+				return err
+			}
+			col.Default = v
+		}
 	}
 
 	return nil
@@ -178,3 +201,40 @@ func (col *Column) SetAI(m *core.Model, vals []string) error {
 
 	return m.SetAutoIncrement(col.Column)
 }
+
+// uuid(v7)
+//
+// 可选值为 v4 和 v7，默认为 v7。
+func (col *Column) SetUUID(vals []string) error {
+	ver := "v7"
+	switch len(vals) {
+	case 0:
+	case 1:
+		ver = strings.ToLower(vals[0])
+	default:
+		return propertyError(col.Name, "uuid", "太多的值")
+	}
+
+	var gen func() (types.UUID, error)
+	switch ver {
+	case "v4":
+		gen = types.NewUUIDv4
+	case "v7":
+		gen = types.NewUUIDv7
+	default:
+		return propertyError(col.Name, "uuid", "无效的版本 "+vals[0])
+	}
+
+	switch col.GoType {
+	case reflect.TypeFor[types.UUID]():
+		col.Generator = func() (any, error) { return gen() }
+	case reflect.TypeFor[types.TextUUID]():
+		col.Generator = func() (any, error) {
+			u, err := gen()
+			return types.TextUUID(u), err
+		}
+	default:
+		return propertyError(col.Name, "uuid", "只能用于 types.UUID 和 types.TextUUID")
+	}
+	return nil
+}
@@ -45,8 +45,23 @@ func TestNewColumn(t *testing.T) {
 		Equal(c.Name, "Name").Equal(c.GoName, "Name").
 		Equal(c.GoType, reflect.TypeFor[T]()).
 		Equal(c.PrimitiveType, core.Int16)
+
+	// 实现了 core.Enumer 接口
+	c, err = model.NewColumn(reflect.StructField{Name: "State", Type: reflect.TypeFor[*state]()})
+	a.NotError(err).NotNil(c).
+		Equal(c.PrimitiveType, core.Int8).
+		Equal(c.Enums, []any{stateNormal, stateLocked})
 }
 
+type state int8
+
+const (
+	stateNormal state = iota + 1
+	stateLocked
+)
+
+func (s state) Enums() []any { return []any{stateNormal, stateLocked} }
+
 func TestColumn_parseTags(t *testing.T) {
 	a := assert.New(t, false)
 	m := &core.Model{
@@ -207,4 +222,45 @@ func TestColumn_setDefault(t *testing.T) {
 	// 格式正确
 	a.NotError(col.SetDefault([]string{nf}))
 	a.Equal(col.Default.(*types.Unix).Time.Unix(), now.Unix())
+
+	// 枚举类型
+
+	col, err = model.NewColumn(reflect.StructField{Name: "state", Type: reflect.TypeFor[state]()})
+	a.NotError(err).NotNil(col)
+	a.NotError(col.SetDefault([]string{"2"})).
+		True(col.HasDefault).
+		Equal(col.Default, int64(2))
+	a.Error(col.SetDefault([]string{"5"}))
+}
+
+func TestColumn_SetUUID(t *testing.T) {
+	a := assert.New(t, false)
+
+	col, err := model.NewColumn(reflect.StructField{Name: "ID", Type: reflect.TypeFor[types.UUID]()})
+	a.NotError(err).Equal(col.PrimitiveType, types.UUIDType)
+	a.NotError(col.SetUUID(nil)).NotNil(col.Generator)
+	v, err := col.Generator()
+	a.NotError(err)
+	u, ok := v.(types.UUID)
+	a.True(ok).False(u.IsZero()).Equal(u[6]>>4, 7)
+
+	a.NotError(col.SetUUID([]string{"V4"}))
+	v, err = col.Generator()
+	a.NotError(err)
+	a.Equal(v.(types.UUID)[6]>>4, 4)
+
+	a.Error(col.SetUUID([]string{"v1"}))
+	a.Error(col.SetUUID([]string{"v4", "v7"}))
+
+	col, err = model.NewColumn(reflect.StructField{Name: "ID", Type: reflect.TypeFor[*types.TextUUID]()})
+	a.NotError(err).Equal(col.PrimitiveType, types.TextUUIDType)
+	a.NotError(col.SetUUID([]string{"v7"}))
+	v, err = col.Generator()
+	a.NotError(err)
+	_, ok = v.(types.TextUUID)
+	a.True(ok)
+
+	// 类型不匹配
+	col, err = model.NewColumn(reflect.StructField{Name: "ID", Type: reflect.TypeFor[string]()})
+	a.NotError(err).Error(col.SetUUID(nil))
 }
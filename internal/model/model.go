@@ -38,6 +38,7 @@ func (ms *Models) New(obj core.TableNamer) (*core.Model, error) {
 
 	m := core.NewModel(core.Table, "#"+obj.TableName(), rtype.NumField())
 	m.GoType = rtype
+	m.Schema = ms.schema
 
 	if err := parseColumns(m, rtype); err != nil {
 		return nil, err
@@ -49,6 +50,10 @@ func (ms *Models) New(obj core.TableNamer) (*core.Model, error) {
 		}
 	}
 
+	if m.Schema != "" && !supportSchema(ms.dialect) {
+		return nil, errSchemaUnsupported(ms.dialect)
+	}
+
 	if view, ok := obj.(core.Viewer); ok {
 		m.Type = core.View
 		sql, err := view.ViewAs()
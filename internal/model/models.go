@@ -6,6 +6,7 @@ package model
 
 import (
 	"database/sql"
+	"fmt"
 	"sync"
 
 	"github.com/issue9/orm/v6/core"
@@ -17,6 +18,7 @@ type Models struct {
 	dialect core.Dialect
 	models  *sync.Map
 	version string
+	schema  string
 }
 
 // NewModels 声明 [Models] 变量
@@ -39,16 +41,50 @@ func NewModels(db *sql.DB, d core.Dialect, tablePrefix string) (*Models, core.En
 
 // Close 清除所有的 [core.Model] 缓存
 func (ms *Models) Close() error {
+	ms.clear()
+	return ms.DB().Close()
+}
+
+func (ms *Models) clear() {
 	ms.models.Range(func(key, _ any) bool {
 		ms.models.Delete(key)
 		return true
 	})
-
-	return ms.DB().Close()
 }
 
 func (ms *Models) DB() *sql.DB { return ms.db }
 
+// SetSchema 指定模型的默认模式
+//
+// 仅对未在 [core.ApplyModeler] 中指定模式的模型有效。
+// 同时会清除所有的 [core.Model] 缓存，以便之后的模型采用新的模式。
+func (ms *Models) SetSchema(schema string) error {
+	if schema != "" && !supportSchema(ms.dialect) {
+		return errSchemaUnsupported(ms.dialect)
+	}
+
+	ms.schema = schema
+	ms.clear()
+	return nil
+}
+
+// 目前仅 postgres 及其兼容的数据库支持模式
+func supportSchema(d core.Dialect) bool {
+	switch d.Name() {
+	case "postgres", "cockroach":
+		return true
+	default:
+		return false
+	}
+}
+
+func errSchemaUnsupported(d core.Dialect) error {
+	return fmt.Errorf("%s 不支持指定模型的模式", d.Name())
+}
+
+// Schema 模型的默认模式
+func (ms *Models) Schema() string { return ms.schema }
+
 func (ms *Models) Version() string { return ms.version }
 
 func (ms *Models) Length() (cnt int) {
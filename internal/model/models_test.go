@@ -69,3 +69,23 @@ func TestModels(t *testing.T) {
 	a.NotError(ms.Close())
 	a.Equal(0, ms.Length())
 }
+
+func TestModels_SetSchema(t *testing.T) {
+	a := assert.New(t, false)
+
+	ms := newModules(a)
+	a.Error(ms.SetSchema("s1")).Empty(ms.Schema())
+	a.NotError(ms.SetSchema(""))
+	a.NotError(ms.Close())
+
+	ms, _ = model.NewOfflineModels(dialect.Postgres("postgres"), "")
+	m, err := ms.New(&User{})
+	a.NotError(err).Empty(m.Schema).Equal(m.FullName(), m.Name)
+
+	a.NotError(ms.SetSchema("s1"))
+	a.Equal(ms.Schema(), "s1").Equal(0, ms.Length())
+	m, err = ms.New(&User{})
+	a.NotError(err).Equal(m.Schema, "s1").Equal(m.FullName(), "s1."+m.Name)
+
+	a.NotError(ms.Close())
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+
+	"github.com/issue9/orm/v6/core"
+)
+
+// 无法建立连接的 [driver.Connector]
+//
+// 由此生成的 [sql.DB] 在执行任何语句时都会返回 [core.ErrOffline]。
+type offlineConnector struct{}
+
+func (offlineConnector) Connect(context.Context) (driver.Conn, error) { return nil, core.ErrOffline }
+
+func (offlineConnector) Driver() driver.Driver { return offlineDriver{} }
+
+type offlineDriver struct{}
+
+func (offlineDriver) Open(string) (driver.Conn, error) { return nil, core.ErrOffline }
+
+// NewOfflineModels 声明不需要连接数据库的 [Models] 变量
+//
+// 返回的 [core.Engine] 仅可用于生成 SQL，执行任何语句都将返回 [core.ErrOffline]。
+func NewOfflineModels(d core.Dialect, tablePrefix string) (*Models, core.Engine) {
+	ms := &Models{
+		db:      sql.OpenDB(offlineConnector{}),
+		dialect: d,
+		models:  &sync.Map{},
+	}
+	return ms, ms.NewEngine(ms.db, tablePrefix)
+}
@@ -0,0 +1,377 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+// Package migrate 版本化的数据库迁移
+//
+// 用户按顺序注册迁移项，每一个迁移项都有唯一的 ID 以及对应的升级和降级操作，
+// 已经执行的迁移项会被记录在数据库的一张数据表中：
+//
+//	m := migrate.New(db, "#migrations")
+//	err := m.Register(&migrate.Migration{
+//	    ID:      "20240101_create_users",
+//	    UpSQL:   []string{"CREATE TABLE #users(id BIGINT NOT NULL)"},
+//	    DownSQL: []string{"DROP TABLE #users"},
+//	}, &migrate.Migration{
+//	    ID:   "20240102_users_data",
+//	    Up:   func(e orm.Engine) error { ... },
+//	    Down: func(e orm.Engine) error { ... },
+//	})
+//	err = m.Up()
+//
+// 如果 [core.Dialect.TransactionalDDL] 为 true，每一个迁移项都会在单独的事务中执行。
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/issue9/orm/v6"
+	"github.com/issue9/orm/v6/core"
+)
+
+type (
+	// Migration 迁移项
+	Migration struct {
+		// 唯一 ID
+		ID string
+
+		// 升级和降级的操作
+		//
+		// 如果为空，则执行 UpSQL 和 DownSQL 中的语句。
+		// 执行环境是事务还是 [orm.DB] 由 [core.Dialect.TransactionalDDL] 决定。
+		Up, Down func(orm.Engine) error
+
+		// 升级和降级需要执行的 SQL 语句
+		//
+		// 语句会依次执行，与 [core.Engine] 相同，# 会被替换为表名前缀。
+		// 仅在 Up 和 Down 为空时有效。
+		UpSQL, DownSQL []string
+	}
+
+	// Migrator 迁移管理
+	Migrator struct {
+		db         *orm.DB
+		table      string
+		migrations []*Migration
+	}
+
+	// Status 迁移项的状态
+	Status struct {
+		ID string
+
+		// 是否已经执行
+		Applied bool
+
+		// 执行的时间
+		//
+		// 仅在 Applied 为 true 时有效。
+		AppliedAt time.Time
+
+		// 执行时记录的校验和与当前的不一致
+		Modified bool
+	}
+
+	record struct {
+		ID       string    `orm:"name(id)"`
+		Checksum string    `orm:"name(checksum)"`
+		Applied  time.Time `orm:"name(applied)"`
+	}
+)
+
+// New 声明 [Migrator] 对象
+//
+// table 为记录迁移历史的数据表名，可以以 # 开头表示添加表名前缀。
+func New(db *orm.DB, table string) *Migrator {
+	return &Migrator{
+		db:         db,
+		table:      table,
+		migrations: make([]*Migration, 0, 10),
+	}
+}
+
+// Register 注册迁移项
+//
+// 迁移项按注册的顺序执行，ID 不能重复。
+func (m *Migrator) Register(ms ...*Migration) error {
+	for _, mm := range ms {
+		if mm.ID == "" {
+			return errors.New("迁移项的 ID 不能为空")
+		}
+
+		if m.find(mm.ID) >= 0 {
+			return fmt.Errorf("迁移项 %s 已经存在", mm.ID)
+		}
+
+		if mm.Up == nil && len(mm.UpSQL) == 0 {
+			return fmt.Errorf("迁移项 %s 未指定升级操作", mm.ID)
+		}
+
+		m.migrations = append(m.migrations, mm)
+	}
+	return nil
+}
+
+// Checksum 迁移项的校验和
+//
+// 根据 UpSQL 和 DownSQL 计算，如果采用的是 Go 函数，则只能对 ID 进行计算。
+func (mm *Migration) Checksum() string {
+	h := sha256.New()
+	h.Write([]byte(mm.ID))
+	for _, q := range mm.UpSQL {
+		h.Write([]byte{0})
+		h.Write([]byte(q))
+	}
+	h.Write([]byte{1})
+	for _, q := range mm.DownSQL {
+		h.Write([]byte{0})
+		h.Write([]byte(q))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Status 返回所有迁移项的状态
+//
+// 顺序与注册的顺序相同。
+func (m *Migrator) Status() ([]*Status, error) {
+	records, err := m.records()
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]*Status, 0, len(m.migrations))
+	for _, mm := range m.migrations {
+		s := &Status{ID: mm.ID}
+		if r, found := records[mm.ID]; found {
+			s.Applied = true
+			s.AppliedAt = r.Applied
+			s.Modified = r.Checksum != mm.Checksum()
+		}
+		status = append(status, s)
+	}
+	return status, nil
+}
+
+// Verify 验证已经执行的迁移项的校验和
+//
+// 如果已执行的迁移项在之后被修改过或是已经不存在，则返回错误。
+func (m *Migrator) Verify() error {
+	records, err := m.records()
+	if err != nil {
+		return err
+	}
+	return m.verify(records)
+}
+
+// Up 执行所有未执行的迁移项
+func (m *Migrator) Up() error {
+	if len(m.migrations) == 0 {
+		return nil
+	}
+	return m.UpTo(m.migrations[len(m.migrations)-1].ID)
+}
+
+// UpTo 执行未执行的迁移项直到 id 为止
+//
+// id 对应的迁移项也会被执行。
+func (m *Migrator) UpTo(id string) error {
+	index := m.find(id)
+	if index < 0 {
+		return fmt.Errorf("迁移项 %s 不存在", id)
+	}
+
+	return m.lock(func(records map[string]*record) error {
+		for _, mm := range m.migrations[:index+1] {
+			if _, found := records[mm.ID]; found {
+				continue
+			}
+
+			if err := m.run(mm, true); err != nil {
+				return fmt.Errorf("迁移项 %s 执行失败: %w", mm.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Down 回滚最后一个已执行的迁移项
+func (m *Migrator) Down() error {
+	return m.lock(func(records map[string]*record) error {
+		for _, mm := range slices.Backward(m.migrations) {
+			if _, found := records[mm.ID]; found {
+				return m.down(mm)
+			}
+		}
+		return nil
+	})
+}
+
+// DownTo 回滚所有在 id 之后执行的迁移项
+//
+// id 对应的迁移项不会被回滚，如果 id 为空，表示回滚所有的迁移项。
+func (m *Migrator) DownTo(id string) error {
+	index := -1
+	if id != "" {
+		if index = m.find(id); index < 0 {
+			return fmt.Errorf("迁移项 %s 不存在", id)
+		}
+	}
+
+	return m.lock(func(records map[string]*record) error {
+		for _, mm := range slices.Backward(m.migrations[index+1:]) {
+			if _, found := records[mm.ID]; !found {
+				continue
+			}
+
+			if err := m.down(mm); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (m *Migrator) down(mm *Migration) error {
+	if mm.Down == nil && len(mm.DownSQL) == 0 {
+		return fmt.Errorf("迁移项 %s 未指定降级操作", mm.ID)
+	}
+
+	if err := m.run(mm, false); err != nil {
+		return fmt.Errorf("迁移项 %s 回滚失败: %w", mm.ID, err)
+	}
+	return nil
+}
+
+func (m *Migrator) find(id string) int {
+	return slices.IndexFunc(m.migrations, func(mm *Migration) bool { return mm.ID == id })
+}
+
+func (m *Migrator) verify(records map[string]*record) error {
+	for id, r := range records {
+		index := m.find(id)
+		if index < 0 {
+			return fmt.Errorf("已执行的迁移项 %s 未注册", id)
+		}
+		if r.Checksum != m.migrations[index].Checksum() {
+			return fmt.Errorf("已执行的迁移项 %s 的内容被修改", id)
+		}
+	}
+	return nil
+}
+
+// 执行迁移项 mm 并更新记录
+func (m *Migrator) run(mm *Migration, up bool) error {
+	f := mm.Down
+	queries := mm.DownSQL
+	if up {
+		f = mm.Up
+		queries = mm.UpSQL
+	}
+
+	exec := func(e orm.Engine) error {
+		if f != nil {
+			if err := f(e); err != nil {
+				return err
+			}
+		} else {
+			for _, q := range queries {
+				if _, err := e.Exec(q); err != nil {
+					return err
+				}
+			}
+		}
+
+		var err error
+		if up {
+			_, err = e.SQLBuilder().Insert().Table(m.table).
+				KeyValue("id", mm.ID).
+				KeyValue("checksum", mm.Checksum()).
+				KeyValue("applied", time.Now()).
+				Exec()
+		} else {
+			_, err = e.SQLBuilder().Delete().Table(m.table).Where("id=?", mm.ID).Exec()
+		}
+		return err
+	}
+
+	if !m.db.Dialect().TransactionalDDL() {
+		return exec(m.db)
+	}
+	return m.db.DoTransaction(func(tx *orm.Tx) error { return exec(tx) })
+}
+
+// 在锁定的状态下执行 f
+//
+// records 为已经执行的迁移项，在执行 f 之前会对其校验和进行验证。
+func (m *Migrator) lock(f func(records map[string]*record) error) (err error) {
+	if l, ok := m.db.Dialect().(core.AdvisoryLocker); ok {
+		ctx := context.Background()
+		var conn *sql.Conn
+		if conn, err = m.db.DB().Conn(ctx); err != nil {
+			return err
+		}
+		defer func() { err = errors.Join(err, conn.Close()) }()
+
+		name := strings.ReplaceAll(m.table, "#", m.db.TablePrefix())
+		exec := func(query string, args []any) error {
+			query, args, err := m.db.Dialect().Fix(query, args)
+			if err == nil {
+				_, err = conn.ExecContext(ctx, query, args...)
+			}
+			return err
+		}
+
+		if err = exec(l.LockSQL(name)); err != nil {
+			return err
+		}
+		defer func() { err = errors.Join(err, exec(l.UnlockSQL(name))) }()
+	}
+
+	// 获得锁之后才读取记录，其它实例可能已经执行了部分迁移项。
+	records, err := m.records()
+	if err != nil {
+		return err
+	}
+	if err = m.verify(records); err != nil {
+		return err
+	}
+	return f(records)
+}
+
+func (m *Migrator) createTable() error {
+	return m.db.SQLBuilder().CreateTable().
+		Table(m.table).
+		Column("id", core.String, false, false, false, nil, 255).
+		Column("checksum", core.String, false, false, false, nil, 64).
+		Column("applied", core.Time, false, false, false, nil).
+		PK(m.table+"_pk", "id").
+		Exec()
+}
+
+func (m *Migrator) records() (map[string]*record, error) {
+	if err := m.createTable(); err != nil {
+		return nil, err
+	}
+
+	records := make([]*record, 0, len(m.migrations))
+	_, err := m.db.SQLBuilder().Select().
+		Columns("id", "checksum", "applied").
+		From(m.table).
+		QueryObject(true, &records)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]*record, len(records))
+	for _, r := range records {
+		ret[r.ID] = r
+	}
+	return ret, nil
+}
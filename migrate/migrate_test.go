@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package migrate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+
+	"github.com/issue9/orm/v6"
+	"github.com/issue9/orm/v6/internal/test"
+	"github.com/issue9/orm/v6/migrate"
+)
+
+func TestMain(m *testing.M) {
+	test.Main(m)
+}
+
+func newMigrations() []*migrate.Migration {
+	return []*migrate.Migration{
+		{
+			ID:      "1_create",
+			UpSQL:   []string{"CREATE TABLE #migrate_users({id} BIGINT NOT NULL, {name} VARCHAR(20) NOT NULL)"},
+			DownSQL: []string{"DROP TABLE #migrate_users"},
+		},
+		{
+			ID: "2_insert",
+			Up: func(e orm.Engine) error {
+				_, err := e.SQLBuilder().Insert().Table("#migrate_users").KeyValue("id", 1).KeyValue("name", "n1").Exec()
+				return err
+			},
+			Down: func(e orm.Engine) error {
+				_, err := e.SQLBuilder().Delete().Table("#migrate_users").Where("id=?", 1).Exec()
+				return err
+			},
+		},
+		{
+			ID:      "3_insert",
+			UpSQL:   []string{"INSERT INTO #migrate_users({id},{name}) VALUES(2,'n2')"},
+			DownSQL: []string{"DELETE FROM #migrate_users WHERE {id}=2"},
+		},
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		defer func() {
+			_, err := t.DB.Exec("DROP TABLE IF EXISTS #migrations")
+			t.NotError(err)
+			_, err = t.DB.Exec("DROP TABLE IF EXISTS #migrate_users")
+			t.NotError(err)
+		}()
+
+		count := func() int64 {
+			n, err := t.DB.SQLBuilder().Select().Count("count(*) AS cnt").From("#migrate_users").QueryInt("cnt")
+			t.NotError(err)
+			return n
+		}
+
+		m := migrate.New(t.DB, "#migrations")
+		t.NotError(m.Register(newMigrations()...))
+		t.ErrorString(m.Register(&migrate.Migration{ID: "1_create", UpSQL: []string{"SELECT 1"}}), "1_create").
+			Error(m.Register(&migrate.Migration{ID: "4"}))
+
+		status, err := m.Status()
+		t.NotError(err).Length(status, 3)
+		for _, s := range status {
+			t.False(s.Applied)
+		}
+
+		t.NotError(m.UpTo("2_insert"))
+		t.Equal(count(), 1)
+		status, err = m.Status()
+		t.NotError(err).
+			True(status[0].Applied).False(status[0].AppliedAt.IsZero()).
+			True(status[1].Applied).
+			False(status[2].Applied)
+
+		t.NotError(m.Up())
+		t.Equal(count(), 2)
+		t.NotError(m.Up()) // 不会重复执行
+
+		t.NotError(m.Down())
+		t.Equal(count(), 1)
+		status, err = m.Status()
+		t.NotError(err).True(status[0].Applied).True(status[1].Applied).False(status[2].Applied)
+
+		t.NotError(m.Up()).NotError(m.DownTo("1_create"))
+		t.Equal(count(), 0)
+		status, err = m.Status()
+		t.NotError(err).True(status[0].Applied).False(status[1].Applied).False(status[2].Applied)
+
+		// 修改了已经执行的迁移项
+		m2 := migrate.New(t.DB, "#migrations")
+		ms := newMigrations()
+		ms[0].UpSQL = append(ms[0].UpSQL, "SELECT 1")
+		t.NotError(m2.Register(ms...))
+		t.Error(m2.Verify()).Error(m2.Up())
+		status, err = m2.Status()
+		t.NotError(err).True(status[0].Modified).False(status[1].Modified)
+
+		// 执行失败
+		m3 := migrate.New(t.DB, "#migrations")
+		ms = newMigrations()
+		ms[2].Up = func(orm.Engine) error { return errors.New("up error") }
+		t.NotError(m3.Register(ms...))
+		t.ErrorString(m3.Up(), "up error")
+		status, err = m3.Status()
+		t.NotError(err).True(status[1].Applied).False(status[2].Applied)
+
+		t.NotError(m.DownTo(""))
+		status, err = m.Status()
+		t.NotError(err)
+		for _, s := range status {
+			t.False(s.Applied)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package orm
+
+import (
+	"strings"
+
+	"github.com/issue9/orm/v6/core"
+	"github.com/issue9/orm/v6/internal/model"
+	"github.com/issue9/orm/v6/sqlbuilder"
+)
+
+// NewOffline 声明一个不连接数据库的 [DB] 实例
+//
+// 返回的对象只能用于生成 SQL 语句，比如代码生成、测试用例以及在 CI 中生成迁移脚本等，
+// 执行任何语句都会返回 [core.ErrOffline]，包括查询语句，[DB.Version] 始终返回空值。
+// 可以通过 [DB.Render] 和 [DB.RenderDDL] 将语句转换成实际的 SQL，
+// 或是配合 [DB.DryRun] 记录 [DB.Create] 等操作生成的语句：
+//
+//	db := orm.NewOffline("prefix_", dialect.Mysql("mysql"))
+//	query, args, err := db.Render(db.SQLBuilder().Select().Column("*").From("#users"))
+//
+//	script := db.DryRun()
+//	err = script.Create(&User{})
+//	err = script.WriteScript(os.Stdout, true)
+func NewOffline(tablePrefix string, dialect Dialect) *DB {
+	ms, e := model.NewOfflineModels(dialect, tablePrefix)
+	return &DB{
+		Engine:      e,
+		tablePrefix: tablePrefix,
+		sqlBuilder:  sqlbuilder.New(e),
+		models:      ms,
+	}
+}
+
+// Render 将 stmt 转换成可在当前数据库上执行的 SQL
+//
+// 与 [core.Engine] 执行语句时的处理相同，表名前缀和引号都会被替换为实际的值，
+// 同时也会经过 [Dialect.Fix] 的处理。
+func (db *DB) Render(stmt sqlbuilder.SQLer) (query string, args []any, err error) {
+	if query, args, err = stmt.SQL(); err != nil {
+		return "", nil, err
+	}
+
+	if query, args, err = db.Dialect().Fix(query, args); err != nil {
+		return "", nil, err
+	}
+	return db.replacer().Replace(query), args, nil
+}
+
+// RenderDDL 将 stmt 转换成可在当前数据库上执行的 SQL
+//
+// 处理方式与 [DB.Render] 相同。
+func (db *DB) RenderDDL(stmt sqlbuilder.DDLSQLer) ([]string, error) {
+	qs, err := stmt.DDLSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	r := db.replacer()
+	ret := make([]string, 0, len(qs))
+	for _, q := range qs {
+		q, _, err := db.Dialect().Fix(q, nil)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, r.Replace(q))
+	}
+	return ret, nil
+}
+
+// 替换语句中的引号和表名前缀
+func (db *DB) replacer() *strings.Replacer {
+	l, r := db.Dialect().Quotes()
+	return strings.NewReplacer(
+		string(core.QuoteLeft), string(l),
+		string(core.QuoteRight), string(r),
+		"#", db.TablePrefix(),
+	)
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package orm_test
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+
+	"github.com/issue9/orm/v6"
+	"github.com/issue9/orm/v6/core"
+	"github.com/issue9/orm/v6/dialect"
+)
+
+func TestNewOffline(t *testing.T) {
+	a := assert.New(t, false)
+
+	db := orm.NewOffline("p_", dialect.Postgres("postgres"))
+	a.NotNil(db).Empty(db.Version())
+
+	query, args, err := db.Render(db.SQLBuilder().Select().Column("*").From("#account").Where("uid=?", 1))
+	a.NotError(err).
+		Equal(query, `SELECT * FROM "p_account" WHERE  uid=$1`).
+		Equal(args, []any{1})
+
+	qs, err := db.RenderDDL(db.SQLBuilder().DropTable().Table("#account"))
+	a.NotError(err).Equal(qs, []string{`DROP TABLE IF EXISTS "p_account"`})
+
+	// 执行语句
+	_, err = db.Exec("SELECT 1")
+	a.ErrorIs(err, core.ErrOffline)
+	_, err = db.Query("SELECT 1")
+	a.ErrorIs(err, core.ErrOffline)
+	a.ErrorIs(db.QueryRow("SELECT 1").Scan(), core.ErrOffline)
+	_, err = db.Prepare("SELECT 1")
+	a.ErrorIs(err, core.ErrOffline)
+	_, err = db.Insert(&Account{UID: 1})
+	a.ErrorIs(err, core.ErrOffline)
+	a.ErrorIs(db.SQLBuilder().DropTable().Table("#account").Exec(), core.ErrOffline)
+	_, err = db.Begin()
+	a.ErrorIs(err, core.ErrOffline)
+
+	// DryRun
+	script := db.DryRun()
+	a.NotError(script.Create(&Account{}))
+	buf := &bytes.Buffer{}
+	a.NotError(script.WriteScript(buf, false)).
+		Contains(buf.String(), `CREATE TABLE IF NOT EXISTS "p_account"`)
+
+	// 带参数的语句，参数转换成字面量。
+	_, err = script.Exec("UPDATE {#account} SET {name}=? WHERE {uid}=?", "a'b", 1)
+	a.NotError(err)
+	_, err = script.Exec("DELETE FROM {#account} WHERE {uid}=@uid", sql.Named("uid", 2))
+	a.NotError(err)
+	_, err = script.Exec("DELETE FROM {#account} WHERE {uid}=?", 1, 2)
+	a.Error(err)
+	buf.Reset()
+	a.NotError(script.WriteScript(buf, false)).
+		Contains(buf.String(), `UPDATE "p_account" SET "name"='a''b' WHERE "uid"=1;`).
+		Contains(buf.String(), `DELETE FROM "p_account" WHERE "uid"=2;`)
+
+	a.NotError(db.Close())
+
+	db = orm.NewOffline("p_", dialect.Sqlite3("sqlite3"))
+	a.Error(db.SetSchema("app")).
+		Error(db.DryRun().Create(&AuditAccount{})) // 在 ApplyModel 中指定了模式
+	a.NotError(db.Close())
+}
+
+// AuditAccount 在 ApplyModel 中指定了模式
+type AuditAccount struct {
+	Account
+}
+
+func (a *AuditAccount) ApplyModel(m *core.Model) error {
+	m.Schema = "audit"
+	return nil
+}
+
+func TestDB_SetSchema(t *testing.T) {
+	a := assert.New(t, false)
+
+	db := orm.NewOffline("p_", dialect.Postgres("postgres"))
+	a.Empty(db.Schema())
+	a.NotError(db.SetSchema("app")).Equal(db.Schema(), "app")
+
+	script := db.DryRun()
+	a.NotError(script.Create(&Account{}, &AuditAccount{})).
+		NotError(script.Truncate(&Account{})).
+		NotError(script.Drop(&AuditAccount{}))
+	buf := &bytes.Buffer{}
+	a.NotError(script.WriteScript(buf, false))
+	a.Contains(buf.String(), `CREATE TABLE IF NOT EXISTS "app"."p_account"`).
+		Contains(buf.String(), `CREATE TABLE IF NOT EXISTS "audit"."p_account"`).
+		Contains(buf.String(), `CONSTRAINT "p_account__pk" PRIMARY KEY`).
+		Contains(buf.String(), `TRUNCATE TABLE "app"."p_account"`).
+		Contains(buf.String(), `DROP TABLE IF EXISTS "audit"."p_account"`)
+
+	query, args, err := db.Render(db.SQLBuilder().Select().Column("*").From("app.#account").Where("uid=?", 1))
+	a.NotError(err).
+		Equal(query, `SELECT * FROM "app"."p_account" WHERE  uid=$1`).
+		Equal(args, []any{1})
+
+	qs, err := db.RenderDDL(db.SQLBuilder().DropIndex().Table("app.#account").Name("p_account_idx"))
+	a.NotError(err).Equal(qs, []string{`DROP INDEX "app"."p_account_idx"`})
+
+	a.NotError(db.Close())
+
+	db = orm.NewOffline("p_", dialect.Sqlite3("sqlite3"))
+	a.Error(db.SetSchema("app")).
+		Error(db.DryRun().Create(&AuditAccount{})) // 在 ApplyModel 中指定了模式
+	a.NotError(db.Close())
+}
+
+func TestNewOffline_enum(t *testing.T) {
+	a := assert.New(t, false)
+
+	db := orm.NewOffline("p_", dialect.Postgres("postgres"))
+	defer func() { a.NotError(db.Close()) }()
+
+	script := db.DryRun()
+	a.NotError(script.Create(&u3{})).
+		NotError(script.Drop(&u3{}))
+	buf := &bytes.Buffer{}
+	a.NotError(script.WriteScript(buf, false))
+	a.Contains(buf.String(), `CREATE TYPE "p_upgrade_enums_state_enum" AS ENUM('draft','published')`).
+		Contains(buf.String(), `IF NOT enum_range(NULL::"p_upgrade_enums_state_enum")::text[] @> ARRAY['draft','published']::text[] THEN RAISE; END IF;`).
+		Contains(buf.String(), `"state" "p_upgrade_enums_state_enum" NOT NULL DEFAULT 'draft'`).
+		Contains(buf.String(), `CONSTRAINT "p_upgrade_enums_level_enum" CHECK("level" IN(1,2))`).
+		Contains(buf.String(), `DROP TABLE IF EXISTS "p_upgrade_enums";`).
+		Contains(buf.String(), `DROP TYPE IF EXISTS "p_upgrade_enums_state_enum";`)
+
+	qs, err := db.RenderDDL(db.SQLBuilder().DropColumn().Table("#upgrade_enums").Column("state").Enum())
+	a.NotError(err).Equal(qs, []string{
+		`ALTER TABLE "p_upgrade_enums" DROP COLUMN "state"`,
+		`DROP TYPE IF EXISTS "p_upgrade_enums_state_enum"`,
+	})
+
+	// 未指定 Enum，不会删除类型
+	qs, err = db.RenderDDL(db.SQLBuilder().DropColumn().Table("#upgrade_enums").Column("level"))
+	a.NotError(err).Equal(qs, []string{`ALTER TABLE "p_upgrade_enums" DROP COLUMN "level"`})
+}
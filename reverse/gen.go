@@ -0,0 +1,267 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package reverse
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/issue9/orm/v6/core"
+)
+
+// 常见的缩写词，生成的字段名中会全部大写。
+var initialisms = map[string]bool{
+	"API": true, "CPU": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true,
+	"IP": true, "JSON": true, "SQL": true, "UID": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// 数据库中常见的时间格式
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+	time.RFC3339Nano,
+}
+
+// 将 models 生成 Go 代码
+func render(pkg, prefix string, models []*core.Model) ([]byte, error) {
+	body := &bytes.Buffer{}
+	imports := map[string]bool{}
+	names := map[string]bool{}
+
+	for _, m := range models {
+		table := strings.TrimPrefix(m.Name, prefix)
+		name := uniqueName(names, goName(table))
+
+		fmt.Fprintf(body, "// %s 对应数据表 %s\n", name, m.Name)
+		fmt.Fprintf(body, "type %s struct {\n", name)
+		fields := map[string]bool{}
+		for _, col := range m.Columns {
+			typ, err := goType(col, imports)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", m.Name, err)
+			}
+
+			tag := "orm:" + strconv.Quote(columnTag(m, prefix, col))
+			if strings.IndexByte(tag, '`') >= 0 {
+				tag = strconv.Quote(tag)
+			} else {
+				tag = "`" + tag + "`"
+			}
+			fmt.Fprintf(body, "%s %s %s\n", uniqueName(fields, goName(col.Name)), typ, tag)
+		}
+		body.WriteString("}\n\n")
+
+		fmt.Fprintf(body, "func (*%s) TableName() string { return %s }\n\n", name, strconv.Quote(table))
+
+		if len(m.Checks) > 0 { // CHECK 约束无法通过标签表示
+			imports["github.com/issue9/orm/v6/core"] = true
+			fmt.Fprintf(body, "func (*%s) ApplyModel(m *core.Model) error {\n", name)
+			for _, k := range slices.Sorted(maps.Keys(m.Checks)) {
+				fmt.Fprintf(body, "if err := m.NewCheck(%s, %s); err != nil {\nreturn err\n}\n", strconv.Quote(constraintName(m.Name, k)), strconv.Quote(m.Checks[k]))
+			}
+			body.WriteString("return nil\n}\n\n")
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("// 由 github.com/issue9/orm/v6/reverse 从数据库中生成，可根据需要自行修改。\n\n")
+	fmt.Fprintf(buf, "package %s\n\n", pkg)
+	if len(imports) > 0 {
+		buf.WriteString("import (\n")
+		std := false
+		for _, path := range []string{"database/sql", "time"} {
+			if imports[path] {
+				fmt.Fprintf(buf, "%s\n", strconv.Quote(path))
+				std = true
+			}
+		}
+		if path := "github.com/issue9/orm/v6/core"; imports[path] {
+			if std {
+				buf.WriteByte('\n')
+			}
+			fmt.Fprintf(buf, "%s\n", strconv.Quote(path))
+		}
+		buf.WriteString(")\n\n")
+	}
+	buf.Write(body.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+// 生成列的 orm 标签内容
+func columnTag(m *core.Model, prefix string, col *core.Column) string {
+	tags := make([]string, 0, 5)
+	tags = append(tags, "name("+col.Name+")")
+
+	if len(col.Length) > 0 {
+		l := make([]string, 0, len(col.Length))
+		for _, v := range col.Length {
+			l = append(l, strconv.Itoa(v))
+		}
+		tags = append(tags, "len("+strings.Join(l, ",")+")")
+	}
+
+	if col.Nullable {
+		tags = append(tags, "nullable")
+	}
+
+	if col.AI {
+		tags = append(tags, "ai")
+	}
+
+	if m.PrimaryKey != nil && slices.Contains(m.PrimaryKey.Columns, col) {
+		tags = append(tags, "pk")
+	}
+
+	for _, u := range m.Uniques {
+		if slices.Contains(u.Columns, col) {
+			tags = append(tags, "unique("+constraintName(m.Name, u.Name)+")")
+		}
+	}
+
+	for _, i := range m.Indexes {
+		if slices.Contains(i.Columns, col) {
+			tags = append(tags, "index("+constraintName(m.Name, i.Name)+")")
+		}
+	}
+
+	for _, fk := range m.ForeignKeys {
+		if fk.Column != col {
+			continue
+		}
+
+		ref := strings.TrimPrefix(fk.RefTableName, prefix) // 标签中的表名会自动加上表名前缀
+		args := []string{constraintName(m.Name, fk.Name), ref, fk.RefColName}
+		if fk.UpdateRule != "" || fk.DeleteRule != "" {
+			args = append(args, fk.UpdateRule, fk.DeleteRule)
+		}
+		tags = append(tags, "fk("+strings.Join(args, ",")+")")
+	}
+
+	if v, ok := defaultValue(col); ok {
+		tags = append(tags, "default("+v+")")
+	}
+
+	return strings.Join(tags, ";")
+}
+
+// 转换成 default 标签可以接受的值
+//
+// 包含标签分隔符的值无法表示，会被忽略。
+func defaultValue(col *core.Column) (string, bool) {
+	if !col.HasDefault {
+		return "", false
+	}
+
+	v := fmt.Sprint(col.Default)
+	if strings.ContainsAny(v, ",;()") {
+		return "", false
+	}
+
+	switch col.PrimitiveType {
+	case core.Bool:
+		switch v {
+		case "1":
+			return "true", true
+		case "0":
+			return "false", true
+		}
+	case core.Time: // 时间格式的默认值需要转换成 RFC3339
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t.Format(core.TimeFormatLayout), true
+			}
+		}
+		return "", false
+	}
+
+	return v, true
+}
+
+// 列对应的 Go 类型，imports 用于记录需要导入的包。
+func goType(col *core.Column, imports map[string]bool) (string, error) {
+	var typ string
+
+	if col.Nullable {
+		switch col.PrimitiveType {
+		case core.Bool:
+			typ = "sql.NullBool"
+		case core.Int8, core.Int16, core.Uint8:
+			typ = "sql.NullInt16"
+		case core.Int32, core.Uint16:
+			typ = "sql.NullInt32"
+		case core.Int, core.Int64, core.Uint, core.Uint32, core.Uint64:
+			typ = "sql.NullInt64"
+		case core.Float32, core.Float64, core.Decimal:
+			typ = "sql.NullFloat64"
+		case core.String:
+			typ = "sql.NullString"
+		case core.Time:
+			typ = "sql.NullTime"
+		}
+
+		if typ != "" {
+			imports["database/sql"] = true
+			return typ, nil
+		}
+	}
+
+	switch col.PrimitiveType {
+	case core.Bytes:
+		return "[]byte", nil
+	case core.Time:
+		imports["time"] = true
+		return "time.Time", nil
+	case core.Decimal: // 没有与 Decimal 直接对应的 Go 类型
+		return "float64", nil
+	case core.Auto:
+		return "", core.ErrInvalidColumnType()
+	default:
+		return col.PrimitiveType.String(), nil
+	}
+}
+
+// 将数据库中的名称转换成 Go 的标识符
+//
+// user_info => UserInfo，user_id => UserID。
+func goName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+
+	b := &strings.Builder{}
+	for _, w := range words {
+		if upper := strings.ToUpper(w); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+
+		rs := []rune(w)
+		b.WriteRune(unicode.ToUpper(rs[0]))
+		b.WriteString(string(rs[1:]))
+	}
+
+	ret := b.String()
+	if ret == "" || !unicode.IsLetter([]rune(ret)[0]) {
+		ret = "F" + ret
+	}
+	return ret
+}
+
+// 如果 name 已经存在于 names，则添加数字后缀。
+func uniqueName(names map[string]bool, name string) string {
+	n := name
+	for i := 2; names[n]; i++ {
+		n = name + strconv.Itoa(i)
+	}
+	names[n] = true
+	return n
+}
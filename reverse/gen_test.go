@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package reverse
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v4"
+
+	"github.com/issue9/orm/v6/core"
+)
+
+func TestGoName(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(goName("users"), "Users").
+		Equal(goName("user_info"), "UserInfo").
+		Equal(goName("user_id"), "UserID").
+		Equal(goName("url"), "URL").
+		Equal(goName("1st"), "F1st").
+		Equal(goName("user-name"), "UserName")
+
+	names := map[string]bool{}
+	a.Equal(uniqueName(names, "ID"), "ID").
+		Equal(uniqueName(names, "ID"), "ID2").
+		Equal(uniqueName(names, "ID"), "ID3")
+}
+
+func TestColumnTag(t *testing.T) {
+	a := assert.New(t, false)
+
+	m := core.NewModel(core.Table, "p_users", 2)
+	id := &core.Column{Name: "id", PrimitiveType: core.Int64}
+	gid := &core.Column{Name: "gid", PrimitiveType: core.Int64, Nullable: true, HasDefault: true, Default: "0"}
+	a.NotError(m.AddColumns(id, gid)).
+		NotError(m.AddPrimaryKey(id)).
+		NotError(m.AddIndex(core.IndexDefault, "p_users_i_gid", gid)).
+		NotError(m.NewForeignKey(&core.ForeignKey{Name: "fk", Column: gid, RefTableName: "p_groups", RefColName: "id", DeleteRule: "CASCADE"}))
+
+	a.Equal(columnTag(m, "p_", id), "name(id);pk").
+		Equal(columnTag(m, "p_", gid), "name(gid);nullable;index(i_gid);fk(fk,groups,id,,CASCADE);default(0)")
+
+	typ, err := goType(gid, map[string]bool{})
+	a.NotError(err).Equal(typ, "sql.NullInt64")
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+// Package reverse 从已有的数据库中反向生成模型代码
+//
+// 通过各个数据库的系统表读取表、列、索引、外键和 CHECK 等信息，
+// 生成带有 orm 标签和 TableName 方法的结构体：
+//
+//	db, _ := orm.NewDB("", "./legacy.db", dialect.Sqlite3("sqlite3"))
+//	f, _ := os.Create("./models/models.go")
+//	err := reverse.Generate(f, db, &reverse.Options{Package: "models"})
+//
+// 表结构由实现了 [core.Introspector] 的 [core.Dialect] 提供，
+// 目前支持 sqlite3、mysql、mariadb 和 postgres。
+package reverse
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/issue9/orm/v6/core"
+)
+
+// Options 生成代码的选项
+type Options struct {
+	// 生成代码的包名
+	//
+	// 如果为空，则采用 models。
+	Package string
+
+	// 表名前缀
+	//
+	// 生成的 TableName 方法会去掉该前缀，与 orm.NewDB 的 tablePrefix 参数相对应。
+	Prefix string
+
+	// 需要生成的表
+	//
+	// 如果为空，表示数据库中的所有表。
+	Tables []string
+}
+
+func introspector(e core.Engine) (core.Introspector, error) {
+	if i, ok := e.Dialect().(core.Introspector); ok {
+		return i, nil
+	}
+	return nil, fmt.Errorf("不支持的数据库 %s", e.Dialect().Name())
+}
+
+// Tables 返回数据库中所有的表名
+//
+// 不包含视图和数据库自身的系统表。
+func Tables(e core.Engine) ([]string, error) {
+	i, err := introspector(e)
+	if err != nil {
+		return nil, err
+	}
+	return i.Tables(e)
+}
+
+// Model 从数据库中读取表 table 的结构
+//
+// 返回对象的 [core.Model.Name] 即为 table，约束名与数据库中的保持一致。
+func Model(e core.Engine, table string) (*core.Model, error) {
+	i, err := introspector(e)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := i.Introspect(e, table)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", table, err)
+	}
+	return m, nil
+}
+
+// Generate 读取数据库中的表结构并将生成的代码写入 w
+func Generate(w io.Writer, e core.Engine, o *Options) error {
+	if o == nil {
+		o = &Options{}
+	}
+	if o.Package == "" {
+		o.Package = "models"
+	}
+
+	tables := o.Tables
+	if len(tables) == 0 {
+		var err error
+		if tables, err = Tables(e); err != nil {
+			return err
+		}
+	}
+
+	models := make([]*core.Model, 0, len(tables))
+	for _, table := range tables {
+		m, err := Model(e, table)
+		if err != nil {
+			return err
+		}
+		models = append(models, m)
+	}
+
+	data, err := render(o.Package, o.Prefix, models)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// 去掉约束名中的表名前缀
+//
+// orm 在创建约束时会以 table_name 的形式命名，生成标签时需要还原。
+func constraintName(table, name string) string {
+	if n, found := strings.CutPrefix(name, table+"_"); found && n != "" {
+		return n
+	}
+	return name
+}
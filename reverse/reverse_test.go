@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package reverse_test
+
+import (
+	"bytes"
+	"database/sql"
+	"go/parser"
+	"go/token"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/issue9/assert/v4"
+
+	"github.com/issue9/orm/v6/core"
+	"github.com/issue9/orm/v6/internal/test"
+	"github.com/issue9/orm/v6/reverse"
+)
+
+type group struct {
+	ID   int64  `orm:"name(id);ai"`
+	Name string `orm:"name(name);len(20);unique(u_name)"`
+}
+
+type user struct {
+	ID       int64          `orm:"name(id);ai"`
+	Name     string         `orm:"name(name);len(20);index(i_name)"`
+	Nickname sql.NullString `orm:"name(nickname);len(50);nullable"`
+	Age      int64          `orm:"name(age);default(18)"`
+	Group    int64          `orm:"name(group);fk(fk_group,groups,id,,CASCADE)"`
+}
+
+type member struct {
+	UID     int64     `orm:"name(uid);pk"`
+	GID     int64     `orm:"name(gid);pk"`
+	Created time.Time `orm:"name(created);default(2019-07-29T17:11:01Z)"`
+}
+
+func (*group) TableName() string { return "groups" }
+
+func (*user) TableName() string { return "users" }
+
+func (*user) ApplyModel(m *core.Model) error {
+	return m.NewCheck("chk_age", "age>=0")
+}
+
+func (*member) TableName() string { return "members" }
+
+func TestMain(m *testing.M) {
+	test.Main(m)
+}
+
+func TestModel(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		t.NotError(t.DB.Create(&group{}, &user{}, &member{}))
+		defer func() {
+			t.NotError(t.DB.Drop(&member{}, &user{}, &group{}))
+		}()
+
+		tables, err := reverse.Tables(t.DB)
+		t.NotError(err).Equal(tables, []string{"groups", "members", "users"})
+
+		m, err := reverse.Model(t.DB, "users")
+		t.NotError(err).NotNil(m).
+			Equal(m.Name, "users").
+			Length(m.Columns, 5).
+			Equal(m.AutoIncrement.Name, "id").
+			Nil(m.PrimaryKey)
+
+		name := m.FindColumn("name")
+		t.Equal(name.PrimitiveType, core.String).False(name.Nullable)
+		if t.Name == "sqlite3" { // sqlite3 的 TEXT 不保存长度
+			t.Equal(name.Length, []int{-1})
+		} else {
+			t.Equal(name.Length, []int{20})
+		}
+		t.True(m.FindColumn("nickname").Nullable)
+		age := m.FindColumn("age")
+		t.True(age.HasDefault).Equal(age.Default, "18")
+
+		t.Length(m.Indexes, 1).Equal(m.Indexes[0].Name, "users_i_name")
+		t.Length(m.ForeignKeys, 1)
+		fk := m.ForeignKeys[0]
+		t.Equal(fk.Name, "users_fk_group").
+			Equal(fk.Column.Name, "group").
+			Equal(fk.RefTableName, "groups").
+			Equal(fk.RefColName, "id").
+			Empty(fk.UpdateRule).
+			Equal(fk.DeleteRule, "CASCADE")
+		t.Length(m.Checks, 1)
+		if t.Name == "sqlite3" { // 其它数据库会格式化表达式
+			t.Equal(m.Checks["users_chk_age"], "age>=0")
+		} else {
+			t.Contains(m.Checks["users_chk_age"], "age")
+		}
+
+		m, err = reverse.Model(t.DB, "groups")
+		t.NotError(err).Length(m.Uniques, 1).
+			Equal(m.Uniques[0].Name, "groups_u_name").
+			Equal(m.Uniques[0].Columns[0].Name, "name")
+
+		m, err = reverse.Model(t.DB, "members")
+		t.NotError(err).Nil(m.AutoIncrement).NotNil(m.PrimaryKey).Length(m.PrimaryKey.Columns, 2)
+		t.Equal(m.PrimaryKey.Columns[0].Name, "uid").Equal(m.PrimaryKey.Columns[1].Name, "gid")
+		t.Equal(m.FindColumn("created").PrimitiveType, core.Time)
+
+		_, err = reverse.Model(t.DB, "not-exists")
+		t.Error(err)
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		t.NotError(t.DB.Create(&group{}, &user{}, &member{}))
+		defer func() {
+			t.NotError(t.DB.Drop(&member{}, &user{}, &group{}))
+		}()
+
+		buf := &bytes.Buffer{}
+		t.NotError(reverse.Generate(buf, t.DB, &reverse.Options{Package: "models"}))
+		_, err := parser.ParseFile(token.NewFileSet(), "models.go", buf.Bytes(), 0)
+		t.NotError(err)
+
+		if t.Name == "sqlite3" {
+			golden, err := os.ReadFile("./testdata/sqlite3.golden")
+			t.NotError(err).Equal(buf.String(), string(golden))
+		}
+	})
+}
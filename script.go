@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package orm
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/issue9/orm/v6/core"
+	"github.com/issue9/orm/v6/sqlbuilder"
+)
+
+var errNotDryRun = errors.New("非 DryRun 模式")
+
+// 仅记录语句而不执行的 [core.Engine]
+//
+// 查询语句依然会执行，部分 DDL 语句的生成需要读取数据库中的表结构。
+type scriptEngine struct {
+	core.Engine
+	replacer *strings.Replacer
+	queries  []string
+}
+
+// DryRun 返回一个只记录语句而不执行的 [DB]
+//
+// 返回对象通过 Exec 执行的语句只会被记录，可以通过 [DB.WriteScript] 输出，
+// 语句中的参数会由 [Dialect.Literal] 转换成字面量，
+// 主要用于 [DB.Create]、[DB.Drop] 和 [Upgrader.Do] 等 DDL 操作，以便在执行之前对语句进行审核。
+// 查询语句依然会在数据库上执行，该模式下也不支持事务。
+//
+// NOTE: 部分数据库的 DDL 语句需要根据数据库中当前的表结构生成，
+// 比如 sqlite3 修改列时需要重建表，此类语句只能反映出数据库的当前状态，
+// 多个此类操作作用于同一张表时，生成的脚本可能并不正确。
+func (db *DB) DryRun() *DB {
+	e := &scriptEngine{
+		Engine:   db.Engine,
+		replacer: db.replacer(),
+		queries:  make([]string, 0, 10),
+	}
+
+	return &DB{
+		Engine:      e,
+		tablePrefix: db.tablePrefix,
+		sqlBuilder:  sqlbuilder.New(e),
+		models:      db.models,
+		dsn:         db.dsn,
+		script:      e,
+	}
+}
+
+// WriteScript 将 [DB.DryRun] 模式下记录的语句写入 w
+//
+// 表名前缀和引号都已经被替换为实际的值，每条语句以分号结尾。
+// 如果 tx 为 true 且 [Dialect.TransactionalDDL] 也为 true，会将所有语句包含在一个事务中。
+// 语句的结束符以及事务的起止语句，可以由实现了 [core.ScriptFormatter] 的 [Dialect] 自定义。
+func (db *DB) WriteScript(w io.Writer, tx bool) error {
+	if db.script == nil {
+		return errNotDryRun
+	}
+
+	begin, commit := "BEGIN;", "COMMIT;"
+	f, _ := db.Dialect().(core.ScriptFormatter)
+	if f != nil {
+		begin, commit = f.ScriptTransaction()
+	}
+
+	tx = tx && db.Dialect().TransactionalDDL()
+	b := &strings.Builder{}
+	if tx && begin != "" {
+		b.WriteString(begin)
+		b.WriteString("\n\n")
+	}
+
+	for _, q := range db.script.queries {
+		if q = strings.TrimSpace(q); f != nil {
+			b.WriteString(f.ScriptStatement(q))
+		} else {
+			b.WriteString(q)
+			b.WriteByte(';')
+		}
+		b.WriteString("\n\n")
+	}
+
+	if tx && commit != "" {
+		b.WriteString(commit)
+		b.WriteByte('\n')
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (e *scriptEngine) Exec(query string, args ...any) (sql.Result, error) {
+	return e.ExecContext(context.Background(), query, args...)
+}
+
+// ExecContext 记录语句
+//
+// 脚本中无法携带参数，args 会由 [Dialect.Literal] 转换成字面量之后替换掉 query 中的占位符。
+func (e *scriptEngine) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	if len(args) > 0 {
+		q, err := sqlbuilder.CombineSQL(e.Dialect(), query, args)
+		if err != nil {
+			return nil, err
+		}
+		query, args = q, nil
+	}
+
+	query, _, err := e.Dialect().Fix(query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	e.queries = append(e.queries, e.replacer.Replace(query))
+	return driver.RowsAffected(0), nil
+}
+
+func (e *scriptEngine) Prepare(query string) (*core.Stmt, error) {
+	return e.PrepareContext(context.Background(), query)
+}
+
+func (e *scriptEngine) PrepareContext(context.Context, string) (*core.Stmt, error) {
+	return nil, errors.New("DryRun 模式不支持预编译语句")
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package orm_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+
+	"github.com/issue9/orm/v6/core"
+	"github.com/issue9/orm/v6/internal/test"
+	"github.com/issue9/orm/v6/sqlbuilder"
+)
+
+func TestDB_DryRun(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "p_")
+
+	suite.Run(func(t *test.Driver) {
+		buf := &bytes.Buffer{}
+		t.Error(t.DB.WriteScript(buf, false)) // 非 DryRun 模式
+
+		l, r := t.DB.Dialect().Quotes()
+		quote := func(s string) string { return string(l) + s + string(r) }
+
+		db := t.DB.DryRun()
+		t.NotError(db.Create(&Account{}))
+		t.NotError(db.Drop(&Account{}))
+
+		// 未真正创建
+		exists, err := sqlbuilder.TableExists(t.DB).Table("#account").Exists()
+		t.NotError(err).False(exists)
+
+		t.NotError(db.WriteScript(buf, false))
+		script := buf.String()
+		t.Contains(script, "CREATE TABLE IF NOT EXISTS "+quote("p_account")).
+			Contains(script, "DROP TABLE IF EXISTS "+quote("p_account")+";\n").
+			NotContains(script, "#").
+			NotContains(script, "{").
+			NotContains(script, "BEGIN;")
+
+		buf.Reset()
+		t.NotError(db.WriteScript(buf, true))
+		if t.DB.Dialect().TransactionalDDL() {
+			t.True(strings.HasPrefix(buf.String(), "BEGIN;\n")).
+				True(strings.HasSuffix(buf.String(), "COMMIT;\n"))
+		}
+
+		// Upgrader
+		sql := t.DB.SQLBuilder().CreateTable().
+			Column("id", core.Int64, false, false, false, nil).
+			Table("#upgrades")
+		t.NotError(sql.Exec())
+		defer func() {
+			t.NotError(t.DB.Drop(&u2{}))
+		}()
+
+		db = t.DB.DryRun()
+		u, err := db.Upgrade(&u2{})
+		t.NotError(err).NotNil(u)
+		t.NotError(u.AddColumn("modified").Do())
+
+		buf.Reset()
+		t.NotError(db.WriteScript(buf, false))
+		t.Contains(buf.String(), quote("p_upgrades")).Contains(buf.String(), quote("modified"))
+
+		// 列未真正添加
+		_, err = t.DB.Exec("SELECT {modified} FROM #upgrades")
+		t.Error(err)
+
+		_, err = db.Begin()
+		t.Error(err)
+	})
+}
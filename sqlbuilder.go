@@ -43,8 +43,20 @@ func getModel(e Engine, v TableNamer) (*core.Model, reflect.Value, error) {
 
 // 根据 Model 中的主键或是唯一索引生成 where 语句，若两者都不存在，则返回错误信息。
 func where(ws *sqlbuilder.WhereStmt, m *core.Model, rval reflect.Value) error {
-	var keys []string
-	var vals []any
+	keys, vals, err := getKeys(m, rval)
+	if err != nil {
+		return err
+	}
+
+	for index, key := range keys {
+		ws.And(string(core.QuoteLeft)+key+string(core.QuoteRight)+"=?", vals[index])
+	}
+
+	return nil
+}
+
+// 根据 Model 中的自增、主键或是唯一约束获取可作为唯一查询条件的列及其值
+func getKeys(m *core.Model, rval reflect.Value) (keys []string, vals []any, err error) {
 	var constraint string
 
 	if m.AutoIncrement != nil {
@@ -67,7 +79,7 @@ func where(ws *sqlbuilder.WhereStmt, m *core.Model, rval reflect.Value) error {
 
 		if len(keys) > 0 {
 			// 可能每个唯一约束查询至的结果是不一样的
-			return fmt.Errorf("多个唯一约束 %s、%s 满足查询条件", constraint, u.Name)
+			return nil, nil, fmt.Errorf("多个唯一约束 %s、%s 满足查询条件", constraint, u.Name)
 		}
 
 		keys, vals = k, v
@@ -76,14 +88,10 @@ func where(ws *sqlbuilder.WhereStmt, m *core.Model, rval reflect.Value) error {
 
 RET:
 	if len(keys) == 0 || len(vals) == 0 {
-		return fmt.Errorf("可作为唯一条件的自增、主键和唯一约束都为空值，无法为 %s 生成查询条件", m.Name)
-	}
-
-	for index, key := range keys {
-		ws.And(string(core.QuoteLeft)+key+string(core.QuoteRight)+"=?", vals[index])
+		return nil, nil, fmt.Errorf("可作为唯一条件的自增、主键和唯一约束都为空值，无法为 %s 生成查询条件", m.Name)
 	}
 
-	return nil
+	return keys, vals, nil
 }
 
 func getKV(rval reflect.Value, cols ...*core.Column) (keys []string, vals []any) {
@@ -100,6 +108,40 @@ func getKV(rval reflect.Value, cols ...*core.Column) (keys []string, vals []any)
 	return keys, vals
 }
 
+// 为 [core.Column.Generator] 不为空且值为零值的列生成值并写回 rval
+func generate(m *core.Model, rval reflect.Value) error {
+	for _, col := range m.Columns {
+		if col.Generator == nil {
+			continue
+		}
+
+		field := rval.FieldByName(col.GoName)
+		if !field.IsValid() {
+			return fmt.Errorf("未找到该名称 %s 的值", col.GoName)
+		}
+		if !field.IsZero() {
+			continue
+		}
+		if !field.CanSet() {
+			return fmt.Errorf("列 %s 需要生成值，但是对象不可修改，请传递指针", col.Name)
+		}
+
+		v, err := col.Generator()
+		if err != nil {
+			return err
+		}
+
+		val := reflect.ValueOf(v)
+		if field.Kind() == reflect.Pointer {
+			ptr := reflect.New(field.Type().Elem())
+			ptr.Elem().Set(val)
+			val = ptr
+		}
+		field.Set(val)
+	}
+	return nil
+}
+
 // 创建表或是视图
 func create(ctx context.Context, e Engine, v TableNamer) error {
 	m, _, err := getModel(e, v)
@@ -111,7 +153,7 @@ func create(ctx context.Context, e Engine, v TableNamer) error {
 		return createView(ctx, e, m)
 	}
 
-	sb := e.SQLBuilder().CreateTable().Table(m.Name)
+	sb := e.SQLBuilder().CreateTable().Table(m.FullName())
 	for _, col := range m.Columns {
 		if col.AI {
 			sb.AutoIncrement(col.Name, col.PrimitiveType)
@@ -157,7 +199,7 @@ func create(ctx context.Context, e Engine, v TableNamer) error {
 }
 
 func createView(ctx context.Context, e Engine, m *core.Model) error {
-	stmt := e.SQLBuilder().CreateView().Name(m.Name)
+	stmt := e.SQLBuilder().CreateView().Name(m.FullName())
 
 	for _, col := range m.Columns {
 		stmt.Column(col.Name)
@@ -177,9 +219,9 @@ func truncate(ctx context.Context, e Engine, v TableNamer) error {
 
 	stmt := e.SQLBuilder().TruncateTable()
 	if m.AutoIncrement != nil {
-		stmt.Table(m.Name, constraintName(m.Name, m.AutoIncrement.Name))
+		stmt.Table(m.FullName(), constraintName(m.Name, m.AutoIncrement.Name))
 	} else {
-		stmt.Table(m.Name, "")
+		stmt.Table(m.FullName(), "")
 	}
 
 	return stmt.ExecContext(ctx)
@@ -193,10 +235,34 @@ func drop(ctx context.Context, e Engine, v TableNamer) error {
 	}
 
 	if m.Type == core.View {
-		return e.SQLBuilder().DropView().Name(m.Name).ExecContext(ctx)
+		return e.SQLBuilder().DropView().Name(m.FullName()).ExecContext(ctx)
+	}
+
+	if err := e.SQLBuilder().DropTable().Table(m.FullName()).ExecContext(ctx); err != nil {
+		return err
 	}
 
-	return e.SQLBuilder().DropTable().Table(m.Name).ExecContext(ctx)
+	// 删除原生枚举列关联的类型
+	hook, ok := e.Dialect().(sqlbuilder.EnumHooker)
+	if !ok {
+		return nil
+	}
+	for _, col := range m.Columns {
+		if !sqlbuilder.IsNativeEnum(e.Dialect(), col) {
+			continue
+		}
+
+		qs, err := hook.DropEnumSQL(m.FullName(), col.Name)
+		if err != nil {
+			return err
+		}
+		for _, q := range qs {
+			if _, err := e.ExecContext(ctx, q); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 func lastInsertID(ctx context.Context, e Engine, v TableNamer) (int64, error) {
@@ -219,7 +285,11 @@ func lastInsertID(ctx context.Context, e Engine, v TableNamer) (int64, error) {
 		}
 	}
 
-	stmt := e.SQLBuilder().Insert().Table(m.Name)
+	if err = generate(m, rval); err != nil {
+		return 0, err
+	}
+
+	stmt := e.SQLBuilder().Insert().Table(m.FullName())
 	for _, col := range m.Columns {
 		field := rval.FieldByName(col.GoName)
 		if !field.IsValid() {
@@ -257,7 +327,11 @@ func insert(ctx context.Context, e Engine, v TableNamer) (sql.Result, error) {
 		}
 	}
 
-	stmt := e.SQLBuilder().Insert().Table(m.Name)
+	if err = generate(m, rval); err != nil {
+		return nil, err
+	}
+
+	stmt := e.SQLBuilder().Insert().Table(m.FullName())
 	for _, col := range m.Columns {
 		field := rval.FieldByName(col.GoName)
 		if !field.IsValid() {
@@ -289,7 +363,7 @@ func find(ctx context.Context, e Engine, v TableNamer) (bool, error) {
 		return false, err
 	}
 
-	stmt := e.SQLBuilder().Select().Column("*").From(m.Name)
+	stmt := e.SQLBuilder().Select().Column("*").From(m.FullName())
 	if err = where(stmt.WhereStmt(), m, rval); err != nil {
 		return false, err
 	}
@@ -318,7 +392,7 @@ func forUpdate(ctx context.Context, tx *Tx, v TableNamer) error {
 		}
 	}
 
-	stmt := tx.SQLBuilder().Select().Column("*").From(m.Name).ForUpdate()
+	stmt := tx.SQLBuilder().Select().Column("*").From(m.FullName()).ForUpdate()
 	if err = where(stmt.WhereStmt(), m, rval); err != nil {
 		return err
 	}
@@ -391,7 +465,7 @@ func getUpdateColumns(e Engine, v TableNamer, stmt *sqlbuilder.UpdateStmt, cols
 		stmt.OCC(m.OCC.Name, occValue)
 	}
 
-	stmt.Table(m.Name)
+	stmt.Table(m.FullName())
 
 	return m, rval, nil
 }
@@ -407,7 +481,7 @@ func del(ctx context.Context, e Engine, v TableNamer) (sql.Result, error) {
 		return nil, fmt.Errorf("模型 %s 的类型是视图，无法从其中删除数据", m.Name)
 	}
 
-	stmt := e.SQLBuilder().Delete().Table(m.Name)
+	stmt := e.SQLBuilder().Delete().Table(m.FullName())
 	if err = where(stmt.WhereStmt(), m, rval); err != nil {
 		return nil, err
 	}
@@ -415,6 +489,122 @@ func del(ctx context.Context, e Engine, v TableNamer) (sql.Result, error) {
 	return stmt.ExecContext(ctx)
 }
 
+var errDeleteManyHasDifferentType = errors.New("DeleteMany 必须是相同的数据类型")
+
+// 每条 DELETE 语句中最多包含的对象数量
+const deleteManyBatchSize = 200
+
+// 批量删除 v 中的对象
+//
+// 根据每个对象的自增、主键或是唯一约束进行分组，
+// 每一组生成 DELETE ... WHERE key IN (...) 或是 (k1,k2) IN ((...),(...)) 形式的语句。
+func delMany(ctx context.Context, e Engine, v ...TableNamer) (int64, error) {
+	if len(v) == 0 {
+		return 0, nil
+	}
+
+	type group struct {
+		keys []string
+		vals [][]any
+	}
+	groups := make([]*group, 0, 2)
+
+	var firstType reflect.Type // 记录数组中第一个元素的类型，保证后面的都相同
+	var m *core.Model
+
+	for i, obj := range v {
+		mm, rval, err := getModel(e, obj)
+		if err != nil {
+			return 0, err
+		}
+
+		if i == 0 {
+			if mm.Type == core.View {
+				return 0, fmt.Errorf("模型 %s 的类型是视图，无法从其中删除数据", mm.Name)
+			}
+			firstType = rval.Type()
+			m = mm
+		} else if firstType != rval.Type() {
+			return 0, errDeleteManyHasDifferentType
+		}
+
+		keys, vals, err := getKeys(m, rval)
+		if err != nil {
+			return 0, err
+		}
+
+		index := slices.IndexFunc(groups, func(g *group) bool { return slices.Equal(g.keys, keys) })
+		if index < 0 {
+			groups = append(groups, &group{keys: keys})
+			index = len(groups) - 1
+		}
+		groups[index].vals = append(groups[index].vals, vals)
+	}
+
+	var affected int64
+	stmt := e.SQLBuilder().Delete()
+	for _, g := range groups {
+		for i := 0; i < len(g.vals); i += deleteManyBatchSize {
+			vals := g.vals[i:min(i+deleteManyBatchSize, len(g.vals))]
+
+			stmt.Reset()
+			stmt.Table(m.FullName())
+			if err := buildDeleteManyWhere(stmt.WhereStmt(), g.keys, vals); err != nil {
+				return 0, err
+			}
+
+			rslt, err := stmt.ExecContext(ctx)
+			if err != nil {
+				return 0, err
+			}
+
+			n, err := rslt.RowsAffected()
+			if err != nil {
+				return 0, err
+			}
+			affected += n
+		}
+	}
+
+	return affected, nil
+}
+
+// 生成 key IN (...) 或是 (k1,k2) IN ((...),(...)) 形式的条件语句
+func buildDeleteManyWhere(ws *sqlbuilder.WhereStmt, keys []string, vals [][]any) error {
+	if len(keys) == 1 {
+		args := make([]any, 0, len(vals))
+		for _, v := range vals {
+			args = append(args, v[0])
+		}
+		ws.AndIn(keys[0], args...)
+		return nil
+	}
+
+	b := core.NewBuilder("(")
+	for _, key := range keys {
+		b.QuoteKey(key).WBytes(',')
+	}
+	b.TruncateLast(1).WString(") IN (")
+
+	args := make([]any, 0, len(vals)*len(keys))
+	for _, v := range vals {
+		b.WBytes('(')
+		for range v {
+			b.WBytes('?', ',')
+		}
+		b.TruncateLast(1).WString("),")
+		args = append(args, v...)
+	}
+	b.TruncateLast(1).WBytes(')')
+
+	cond, err := b.String()
+	if err != nil {
+		return err
+	}
+	ws.And(cond, args...)
+	return nil
+}
+
 var errInsertManyHasDifferentType = errors.New("InsertMany 必须是相同的数据类型")
 
 // rval 为结构体指针组成的数据
@@ -439,9 +629,13 @@ func buildInsertManySQL(e Engine, v ...TableNamer) (*sqlbuilder.InsertStmt, erro
 			return nil, err
 		}
 
+		if err = generate(m, irval); err != nil {
+			return nil, err
+		}
+
 		if i == 0 { // 第一个元素，需要从中获取列信息。
 			firstType = irval.Type()
-			query.Table(m.Name)
+			query.Table(m.FullName())
 
 			for _, col := range m.Columns {
 				field := irval.FieldByName(col.GoName)
@@ -100,7 +100,7 @@ func (stmt *execStmt) CombineSQL() (query string, err error) {
 		return "", err
 	}
 
-	return fillArgs(query, args)
+	return fillArgs(stmt.Dialect(), query, args)
 }
 
 func (stmt *execStmt) Exec() (sql.Result, error) { return stmt.ExecContext(context.Background()) }
@@ -142,7 +142,7 @@ func (stmt *queryStmt) CombineSQL() (query string, err error) {
 		return "", err
 	}
 
-	return fillArgs(query, args)
+	return fillArgs(stmt.Dialect(), query, args)
 }
 
 func (stmt *queryStmt) PrepareContext(ctx context.Context) (*core.Stmt, error) {
@@ -44,6 +44,24 @@ func (stmt *AddColumnStmt) Column(name string, p core.PrimitiveType, ai, nullabl
 	return stmt
 }
 
+// Enums 指定列的可选值
+//
+// 需要在 [AddColumnStmt.Column] 之后调用。
+// 如果数据库不支持原生的枚举类型，与 [CreateTableStmt] 相同，会添加由 [EnumCheckName] 命名的 CHECK 约束。
+func (stmt *AddColumnStmt) Enums(v ...any) *AddColumnStmt {
+	if stmt.err != nil {
+		return stmt
+	}
+
+	if stmt.column == nil {
+		stmt.err = SyntaxError("ALTER TABLE ADD", "未指定列")
+		return stmt
+	}
+
+	stmt.column.Enums = v
+	return stmt
+}
+
 // DDLSQL 获取 SQL 语句以及对应的参数
 func (stmt *AddColumnStmt) DDLSQL() ([]string, error) {
 	if stmt.err != nil {
@@ -62,23 +80,36 @@ func (stmt *AddColumnStmt) DDLSQL() ([]string, error) {
 		return nil, err
 	}
 
-	typ, err := stmt.Dialect().SQLType(stmt.column)
+	typ, sqls, err := columnType(stmt.Dialect(), stmt.table, stmt.column)
 	if err != nil {
 		return nil, err
 	}
 
 	buf := core.NewBuilder("ALTER TABLE ").
-		QuoteKey(stmt.table).
+		QuoteTable(stmt.table).
 		WString(" ADD ").
 		QuoteKey(stmt.column.Name).
 		WBytes(' ').
 		WString(typ)
 
+	if len(stmt.column.Enums) > 0 && !IsNativeEnum(stmt.Dialect(), stmt.column) {
+		expr, err := EnumCheckExpr(stmt.Dialect(), stmt.column)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.WString(" CONSTRAINT ").
+			QuoteKey(EnumCheckName(stmt.table, stmt.column.Name)).
+			WString(" CHECK(").
+			WString(expr).
+			WBytes(')')
+	}
+
 	query, err := buf.String()
 	if err != nil {
 		return nil, err
 	}
-	return []string{query}, nil
+	return append(sqls, query), nil
 }
 
 // Reset 重置
@@ -100,6 +131,7 @@ type DropColumnStmt struct {
 
 	TableName  string
 	ColumnName string
+	enum       bool
 }
 
 // DropColumn 声明一条删除列的语句
@@ -128,6 +160,15 @@ func (stmt *DropColumnStmt) Column(col string) *DropColumnStmt {
 	return stmt
 }
 
+// Enum 表示删除的列是原生的枚举列
+//
+// 对于实现了 [EnumHooker] 的数据库，
+// 会在删除列之后执行 [EnumHooker.DropEnumSQL] 返回的语句，比如删除 postgres 中该列对应的类型。
+func (stmt *DropColumnStmt) Enum() *DropColumnStmt {
+	stmt.enum = true
+	return stmt
+}
+
 // DDLSQL 获取 SQL 语句以及对应的参数
 func (stmt *DropColumnStmt) DDLSQL() ([]string, error) {
 	if stmt.err != nil {
@@ -138,16 +179,111 @@ func (stmt *DropColumnStmt) DDLSQL() ([]string, error) {
 		return nil, SyntaxError("DROP COLUMN", "未指定表名")
 	}
 
+	var qs []string
 	if hook, ok := stmt.Dialect().(DropColumnStmtHooker); ok {
-		return hook.DropColumnStmtHook(stmt)
+		sqls, err := hook.DropColumnStmtHook(stmt)
+		if err != nil {
+			return nil, err
+		}
+		qs = sqls
+	} else {
+		query, err := core.NewBuilder("ALTER TABLE ").
+			QuoteTable(stmt.TableName).
+			WString(" DROP COLUMN ").
+			QuoteKey(stmt.ColumnName).
+			String()
+		if err != nil {
+			return nil, err
+		}
+		qs = []string{query}
 	}
 
-	buf := core.NewBuilder("ALTER TABLE ").
-		QuoteKey(stmt.TableName).
-		WString(" DROP COLUMN ").
-		QuoteKey(stmt.ColumnName)
+	// 删除由枚举列创建的类型
+	if hook, ok := stmt.Dialect().(EnumHooker); ok && stmt.enum {
+		sqls, err := hook.DropEnumSQL(stmt.TableName, stmt.ColumnName)
+		if err != nil {
+			return nil, err
+		}
+		qs = append(qs, sqls...)
+	}
 
-	query, err := buf.String()
+	return qs, nil
+}
+
+// Reset 重置
+func (stmt *DropColumnStmt) Reset() *DropColumnStmt {
+	stmt.baseStmt.Reset()
+	stmt.TableName = ""
+	stmt.ColumnName = ""
+	stmt.enum = false
+	return stmt
+}
+
+// RenameColumnStmtHooker RenameColumnStmt.DDLSQL 的钩子函数
+type RenameColumnStmtHooker interface {
+	RenameColumnStmtHook(*RenameColumnStmt) ([]string, error)
+}
+
+// RenameColumnStmt 重命名列
+type RenameColumnStmt struct {
+	*ddlStmt
+
+	TableName     string
+	OldName, Name string
+}
+
+// RenameColumn 声明一条重命名列的语句
+func (sql *SQLBuilder) RenameColumn() *RenameColumnStmt { return RenameColumn(sql.engine) }
+
+// RenameColumn 声明一条重命名列的语句
+func RenameColumn(e core.Engine) *RenameColumnStmt {
+	stmt := &RenameColumnStmt{}
+	stmt.ddlStmt = newDDLStmt(e, stmt)
+	return stmt
+}
+
+// Table 指定表名
+//
+// NOTE: 重复指定，会覆盖之前的。
+func (stmt *RenameColumnStmt) Table(table string) *RenameColumnStmt {
+	stmt.TableName = table
+	return stmt
+}
+
+// Column 将列 old 重命名为 name
+//
+// NOTE: 重复指定，会覆盖之前的。
+func (stmt *RenameColumnStmt) Column(old, name string) *RenameColumnStmt {
+	stmt.OldName = old
+	stmt.Name = name
+	return stmt
+}
+
+// DDLSQL 获取 SQL 语句以及对应的参数
+func (stmt *RenameColumnStmt) DDLSQL() ([]string, error) {
+	if stmt.err != nil {
+		return nil, stmt.Err()
+	}
+
+	if stmt.TableName == "" {
+		return nil, SyntaxError("RENAME COLUMN", "未指定表名")
+	}
+
+	if stmt.OldName == "" || stmt.Name == "" {
+		return nil, SyntaxError("RENAME COLUMN", "未指定列")
+	}
+
+	if hook, ok := stmt.Dialect().(RenameColumnStmtHooker); ok {
+		return hook.RenameColumnStmtHook(stmt)
+	}
+
+	query, err := core.NewBuilder("ALTER TABLE ").
+		QuoteTable(stmt.TableName).
+		WString(" RENAME COLUMN ").
+		QuoteKey(stmt.OldName).
+		WString(" TO ").
+		QuoteKey(stmt.Name).
+		String()
 	if err != nil {
 		return nil, err
 	}
@@ -155,9 +291,130 @@ func (stmt *DropColumnStmt) DDLSQL() ([]string, error) {
 }
 
 // Reset 重置
-func (stmt *DropColumnStmt) Reset() *DropColumnStmt {
+func (stmt *RenameColumnStmt) Reset() *RenameColumnStmt {
 	stmt.baseStmt.Reset()
 	stmt.TableName = ""
-	stmt.ColumnName = ""
+	stmt.OldName = ""
+	stmt.Name = ""
+	return stmt
+}
+
+// AlterColumnStmtHooker AlterColumnStmt.DDLSQL 的钩子函数
+type AlterColumnStmtHooker interface {
+	AlterColumnStmtHook(*AlterColumnStmt) ([]string, error)
+}
+
+// AlterColumnStmt 修改列的定义
+//
+// 可以修改列的类型、长度、是否可为空以及默认值，
+// 默认采用 mysql 的 MODIFY 语法，其它数据库需要实现 [AlterColumnStmtHooker]。
+type AlterColumnStmt struct {
+	*ddlStmt
+
+	TableName string
+	Col       *core.Column // 列的新定义
+}
+
+// AlterColumn 声明一条修改列的语句
+func (sql *SQLBuilder) AlterColumn() *AlterColumnStmt { return AlterColumn(sql.engine) }
+
+// AlterColumn 声明一条修改列的语句
+func AlterColumn(e core.Engine) *AlterColumnStmt {
+	stmt := &AlterColumnStmt{}
+	stmt.ddlStmt = newDDLStmt(e, stmt)
+	return stmt
+}
+
+// Table 指定表名
+//
+// NOTE: 重复指定，会覆盖之前的。
+func (stmt *AlterColumnStmt) Table(table string) *AlterColumnStmt {
+	stmt.TableName = table
+	return stmt
+}
+
+// Column 指定列的新定义
+//
+// 参数信息可参考 [CreateTableStmt.Column]，name 为需要修改的列名。
+//
+// NOTE: 重复指定，会覆盖之前的。
+func (stmt *AlterColumnStmt) Column(name string, p core.PrimitiveType, ai, nullable, hasDefault bool, def any, length ...int) *AlterColumnStmt {
+	if stmt.err != nil {
+		return stmt
+	}
+
+	stmt.Col, stmt.err = newColumn(name, p, ai, nullable, hasDefault, def, length...)
+	return stmt
+}
+
+// Enums 指定列的可选值
+//
+// 需要在 [AlterColumnStmt.Column] 之后调用。
+// 以 CHECK 约束实现的枚举列，其约束不受影响；
+// 原生的枚举列则无法通过此语句修改，需要使用 [AlterEnumStmt]。
+func (stmt *AlterColumnStmt) Enums(v ...any) *AlterColumnStmt {
+	if stmt.err != nil {
+		return stmt
+	}
+
+	if stmt.Col == nil {
+		stmt.err = SyntaxError("ALTER COLUMN", "未指定列")
+		return stmt
+	}
+
+	stmt.Col.Enums = v
+	return stmt
+}
+
+// DDLSQL 获取 SQL 语句以及对应的参数
+func (stmt *AlterColumnStmt) DDLSQL() ([]string, error) {
+	if stmt.err != nil {
+		return nil, stmt.Err()
+	}
+
+	if stmt.TableName == "" {
+		return nil, SyntaxError("ALTER COLUMN", "未指定表名")
+	}
+
+	if stmt.Col == nil {
+		return nil, SyntaxError("ALTER COLUMN", "未指定列")
+	}
+
+	if err := stmt.Col.Check(); err != nil {
+		return nil, err
+	}
+
+	// 原生枚举的类型与可选值相关，只能由 AlterEnumStmt 修改。
+	if IsNativeEnum(stmt.Dialect(), stmt.Col) {
+		return nil, SyntaxError("ALTER COLUMN", "枚举列 "+stmt.Col.Name+" 需要通过 AlterEnumStmt 修改")
+	}
+
+	if hook, ok := stmt.Dialect().(AlterColumnStmtHooker); ok {
+		return hook.AlterColumnStmtHook(stmt)
+	}
+
+	typ, err := stmt.Dialect().SQLType(stmt.Col)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := core.NewBuilder("ALTER TABLE ").
+		QuoteTable(stmt.TableName).
+		WString(" MODIFY COLUMN ").
+		QuoteKey(stmt.Col.Name).
+		WBytes(' ').
+		WString(typ).
+		String()
+	if err != nil {
+		return nil, err
+	}
+	return []string{query}, nil
+}
+
+// Reset 重置
+func (stmt *AlterColumnStmt) Reset() *AlterColumnStmt {
+	stmt.baseStmt.Reset()
+	stmt.TableName = ""
+	stmt.Col = nil
 	return stmt
 }
@@ -17,6 +17,8 @@ import (
 var (
 	_ sqlbuilder.DDLStmt = &sqlbuilder.AddColumnStmt{}
 	_ sqlbuilder.DDLStmt = &sqlbuilder.DropColumnStmt{}
+	_ sqlbuilder.DDLStmt = &sqlbuilder.RenameColumnStmt{}
+	_ sqlbuilder.DDLStmt = &sqlbuilder.AlterColumnStmt{}
 )
 
 func TestColumn(t *testing.T) {
@@ -93,3 +95,89 @@ func TestColumn(t *testing.T) {
 		a.NotError(err)
 	})
 }
+
+func TestRenameColumn(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		db := t.DB
+
+		err := sqlbuilder.CreateTable(db).
+			Table("users").
+			AutoIncrement("id", core.Int64).
+			Column("name", core.String, false, false, false, nil, 20).
+			Index(core.IndexDefault, "index_users_name", "name").
+			Exec()
+		a.NotError(err)
+		defer func() {
+			err = sqlbuilder.DropTable(db).Table("users").Exec()
+			a.NotError(err)
+		}()
+
+		_, err = sqlbuilder.Insert(db).Table("users").KeyValue("name", "n1").Exec()
+		t.NotError(err)
+
+		stmt := sqlbuilder.RenameColumn(db)
+		err = stmt.Table("users").Column("name", "nickname").Exec()
+		t.NotError(err, "%s@%s", err, t.DriverName)
+
+		name, err := sqlbuilder.Select(db).Column("nickname").From("users").QueryString("nickname")
+		t.NotError(err).Equal(name, "n1")
+
+		err = stmt.Reset().Exec()
+		a.ErrorString(err, "未指定表名")
+
+		err = stmt.Reset().Table("users").Exec()
+		a.ErrorString(err, "未指定列")
+	})
+}
+
+func TestAlterColumn(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		db := t.DB
+
+		err := sqlbuilder.CreateTable(db).
+			Table("users").
+			AutoIncrement("id", core.Int64).
+			Column("name", core.String, false, false, false, nil, 20).
+			Column("age", core.Int64, false, false, false, nil).
+			Exec()
+		a.NotError(err)
+		defer func() {
+			err = sqlbuilder.DropTable(db).Table("users").Exec()
+			a.NotError(err)
+		}()
+
+		_, err = sqlbuilder.Insert(db).Table("users").KeyValue("name", "n1").KeyValue("age", 1).Exec()
+		t.NotError(err)
+
+		stmt := sqlbuilder.AlterColumn(db)
+		err = stmt.Table("users").
+			Column("name", core.String, false, true, true, "def", 50).
+			Exec()
+		t.NotError(err, "%s@%s", err, t.DriverName)
+
+		// name 可以为空，且有默认值
+		_, err = sqlbuilder.Insert(db).Table("users").KeyValue("age", 2).Exec()
+		t.NotError(err)
+		name, err := sqlbuilder.Select(db).Column("name").From("users").Where("age=?", 2).QueryString("name")
+		t.NotError(err).Equal(name, "def")
+
+		// 数据依然存在
+		name, err = sqlbuilder.Select(db).Column("name").From("users").Where("age=?", 1).QueryString("name")
+		t.NotError(err).Equal(name, "n1")
+
+		err = stmt.Reset().Table("users").Column("not_exists", core.Int64, false, true, false, nil).Exec()
+		t.Error(err)
+
+		err = stmt.Reset().Exec()
+		a.ErrorString(err, "未指定表名")
+
+		err = stmt.Reset().Table("users").Exec()
+		a.ErrorString(err, "未指定列")
+	})
+}
@@ -157,7 +157,7 @@ func (stmt *AddConstraintStmt) DDLSQL() ([]string, error) {
 	}
 
 	builder := core.NewBuilder("ALTER TABLE ").
-		QuoteKey(stmt.TableName).
+		QuoteTable(stmt.TableName).
 		WString(" ADD CONSTRAINT ").
 		QuoteKey(stmt.Name)
 
@@ -168,7 +168,7 @@ func (stmt *AddConstraintStmt) DDLSQL() ([]string, error) {
 		builder.WString(" FOREIGN KEY(").
 			QuoteKey(stmt.Data[0]).
 			WString(") REFERENCES ").
-			QuoteKey(stmt.Data[1]).
+			QuoteTable(stmt.Data[1]).
 			Quote(stmt.Data[2], '(', ')')
 
 		if stmt.Data[3] != "" {
@@ -268,7 +268,7 @@ func (stmt *DropConstraintStmt) DDLSQL() ([]string, error) {
 	}
 
 	builder := core.NewBuilder("ALTER TABLE ").
-		QuoteKey(stmt.TableName).
+		QuoteTable(stmt.TableName).
 		WString(" DROP CONSTRAINT ").
 		QuoteKey(stmt.Name)
 
@@ -11,7 +11,10 @@ type DeleteStmt struct {
 	*execStmt
 	*deleteWhere
 
-	table string
+	with       withClause
+	table      string
+	joins      tableJoins
+	orderLimit orderLimit
 }
 
 type deleteWhere = WhereStmtOf[*DeleteStmt]
@@ -44,16 +47,56 @@ func (stmt *DeleteStmt) SQL() (string, []any, error) {
 		return "", nil, SyntaxError("DELETE", "未指定表名")
 	}
 
-	query, args, err := stmt.WhereStmt().SQL()
+	if err := stmt.with.check(stmt.Engine()); err != nil {
+		return "", nil, err
+	}
+
+	builder := core.NewBuilder("")
+	args, err := stmt.with.build(builder)
+	if err != nil {
+		return "", nil, err
+	}
+
+	query, wa, err := stmt.WhereStmt().SQL()
 	if err != nil {
 		return "", nil, err
 	}
 
-	q, err := core.NewBuilder("DELETE FROM ").
-		QuoteKey(stmt.table).
-		WString(" WHERE ").
-		WString(query).
-		String()
+	var suffix string
+	if !stmt.orderLimit.empty() {
+		query, wa, suffix, err = stmt.orderLimit.build(stmt.Dialect(), len(stmt.joins) > 0, stmt.table, query, wa)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	args = append(args, wa...)
+
+	syntax := JoinSubquery
+	if len(stmt.joins) > 0 {
+		syntax = joinSyntax(stmt.Engine(), false)
+	}
+
+	switch {
+	case len(stmt.joins) == 0:
+		builder.WString("DELETE FROM ").QuoteTable(stmt.table).WString(" WHERE ").WString(query)
+	case syntax == JoinMysql:
+		builder.WString("DELETE ").QuoteTable(stmt.table).WString(" FROM ").QuoteTable(stmt.table)
+		stmt.joins.writeJoins(builder)
+		if query != "" {
+			builder.WString(" WHERE ").WString(query)
+		}
+	case syntax == JoinFrom:
+		builder.WString("DELETE FROM ").QuoteTable(stmt.table).WString(" USING ")
+		stmt.joins.writeTables(builder)
+		builder.WString(" WHERE ")
+		stmt.joins.writeConds(builder, query)
+	default:
+		builder.WString("DELETE FROM ").QuoteTable(stmt.table).WString(" WHERE ")
+		stmt.joins.writeExists(builder, query)
+	}
+	builder.WString(suffix)
+
+	q, err := builder.String()
 	if err != nil {
 		return "", nil, err
 	}
@@ -63,7 +106,10 @@ func (stmt *DeleteStmt) SQL() (string, []any, error) {
 // Reset 重置语句
 func (stmt *DeleteStmt) Reset() *DeleteStmt {
 	stmt.baseStmt.Reset()
+	stmt.with.reset()
 	stmt.table = ""
+	stmt.joins.reset()
+	stmt.orderLimit.reset()
 	stmt.WhereStmt().Reset()
 	return stmt
 }
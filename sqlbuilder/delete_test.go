@@ -63,3 +63,129 @@ func TestWhereStmt_Delete(t *testing.T) {
 		sqltest.Equal(a, query, "DELETE FROM {users} WHERE id=?")
 	})
 }
+
+func TestDeleteStmt_With(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		old := sqlbuilder.Select(t.DB).Column("id").From("users").Where("id<?", 3)
+		del := sqlbuilder.Delete(t.DB).
+			WithRecursive("old", old).
+			Table("users").
+			Where("id IN (SELECT id FROM {old})").
+			And("age>?", 1)
+		query, args, err := del.SQL()
+		t.NotError(err).Equal(args, []any{3, 1})
+		sqltest.Equal(a, query, "WITH RECURSIVE {old} AS (SELECT id FROM {users} WHERE id<?) DELETE FROM {users} WHERE id IN (SELECT id FROM {old}) AND age>?")
+
+		r, err := del.Exec()
+		t.NotError(err)
+		cnt, err := r.RowsAffected()
+		t.NotError(err).Equal(cnt, 1)
+	})
+}
+
+func TestDeleteStmt_Join(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		_, err := sqlbuilder.Insert(t.DB).Table("info").
+			Columns("uid", "tel", "nickname", "address").
+			Values(1, "1", "n1", "a1").
+			Values(2, "2", "n2", "a2").
+			Exec()
+		t.NotError(err)
+
+		d := sqlbuilder.Delete(t.DB).
+			Table("users").
+			Join("info", "i", "{i}.{uid}={users}.{id}").
+			Where("{users}.{id}=?", 2)
+		query, args, err := d.SQL()
+		t.NotError(err).Equal(args, []any{2})
+		switch t.Name {
+		case "mysql", "mariadb":
+			sqltest.Equal(a, query, "DELETE {users} FROM {users} INNER JOIN {info} AS {i} ON {i}.{uid}={users}.{id} WHERE {users}.{id}=?")
+		case "postgres":
+			sqltest.Equal(a, query, "DELETE FROM {users} USING {info} AS {i} WHERE ({i}.{uid}={users}.{id}) AND ({users}.{id}=?)")
+		default:
+			sqltest.Equal(a, query, "DELETE FROM {users} WHERE ({users}.{id}=?) AND EXISTS(SELECT 1 FROM {info} AS {i} WHERE ({i}.{uid}={users}.{id}))")
+		}
+
+		r, err := d.Exec()
+		t.NotError(err)
+		cnt, err := r.RowsAffected()
+		t.NotError(err).Equal(cnt, 1)
+
+		cnt, err = sqlbuilder.Select(t.DB).Count("COUNT(*) AS cnt").From("users").Where("id=?", 2).QueryInt("cnt")
+		t.NotError(err).Zero(cnt)
+	})
+}
+
+func TestDeleteStmt_Limit(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		d := sqlbuilder.Delete(t.DB).
+			Table("users").
+			Where("id>?", 1).
+			Asc("id").
+			Limit(2)
+		query, args, err := d.SQL()
+		t.NotError(err).Equal(args, []any{1, 2})
+		switch t.Name {
+		case "mysql", "mariadb":
+			sqltest.Equal(a, query, "DELETE FROM {users} WHERE id>? ORDER BY {id} ASC LIMIT ?")
+		case "postgres":
+			sqltest.Equal(a, query, "DELETE FROM {users} WHERE {ctid} IN (SELECT {ctid} FROM {users} WHERE id>? ORDER BY {id} ASC LIMIT ?)")
+		default:
+			sqltest.Equal(a, query, "DELETE FROM {users} WHERE {rowid} IN (SELECT {rowid} FROM {users} WHERE id>? ORDER BY {id} ASC LIMIT ?)")
+		}
+
+		r, err := d.Exec()
+		t.NotError(err)
+		cnt, err := r.RowsAffected()
+		t.NotError(err).Equal(cnt, 2)
+
+		id, err := sqlbuilder.Select(t.DB).Column("id").From("users").Where("id<?", 5).Asc("id").QueryInt("id")
+		t.NotError(err).Equal(id, 1)
+		cnt, err = sqlbuilder.Select(t.DB).Count("COUNT(*) AS cnt").From("users").Where("id<?", 4).QueryInt("cnt")
+		t.NotError(err).Equal(cnt, 1)
+
+		// 仅有 ORDER BY
+		_, _, err = d.Reset().Table("users").Where("id>?", 1).Asc("id").SQL()
+		switch t.Name {
+		case "mysql", "mariadb":
+			t.NotError(err)
+		default:
+			t.Error(err)
+		}
+
+		// 指定 key
+		d.Reset()
+		d.Table("users").Where("id>?", 1).Desc("id").Limit(1, "id")
+		r, err = d.Exec()
+		t.NotError(err)
+		cnt, err = r.RowsAffected()
+		t.NotError(err).Equal(cnt, 1)
+		cnt, err = sqlbuilder.Select(t.DB).Count("COUNT(*) AS cnt").From("users").Where("id=?", 7).QueryInt("cnt")
+		t.NotError(err).Zero(cnt)
+
+		// 多表
+		d.Reset()
+		d.Table("users").Join("info", "i", "{i}.{uid}={users}.{id}").Where("id>?", 1).Limit(1)
+		_, _, err = d.SQL()
+		t.ErrorString(err, "多表操作不支持")
+	})
+}
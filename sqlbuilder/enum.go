@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package sqlbuilder
+
+import (
+	"strings"
+
+	"github.com/issue9/orm/v6/core"
+)
+
+// EnumHooker 原生支持枚举类型的数据库需要实现的接口
+//
+// 仅 [core.String] 类型的枚举列会采用原生类型，
+// 未实现此接口的数据库以及整数类型的枚举列，都以 CHECK 约束限定其取值范围，
+// 约束名由 [EnumCheckName] 生成。
+type EnumHooker interface {
+	// EnumSQLType 返回表 table 中枚举列 col 的类型
+	//
+	// 与 [core.Dialect.SQLType] 相同，返回值中包含了 NOT NULL 和 DEFAULT 等内容；
+	// ddl 为在创建列之前需要执行的语句，比如 postgres 中的 CREATE TYPE。
+	EnumSQLType(table string, col *core.Column) (typ string, ddl []string, err error)
+
+	// AlterEnumSQL 将表 table 中枚举列 col 的可选值修改为 col.Enums
+	AlterEnumSQL(table string, col *core.Column) ([]string, error)
+
+	// DropEnumSQL 删除表 table 中的枚举列 col 之后需要执行的语句
+	//
+	// 比如删除由 EnumSQLType 创建的类型，不需要任何操作时返回空值。
+	// 仅在明确指定了是原生枚举列时才会调用，比如 [DropColumnStmt.Enum]。
+	DropEnumSQL(table, col string) ([]string, error)
+}
+
+// AlterEnumStmtHooker AlterEnumStmt.DDLSQL 的钩子函数
+//
+// 仅在以 CHECK 约束实现枚举时调用，未实现该接口则先删除约束再重新添加。
+type AlterEnumStmtHooker interface {
+	AlterEnumStmtHook(*AlterEnumStmt) ([]string, error)
+}
+
+// AlterEnumStmt 修改枚举列的可选值
+type AlterEnumStmt struct {
+	*ddlStmt
+
+	TableName string
+	Col       *core.Column
+}
+
+// IsNativeEnum 在数据库 d 中列 col 是否以原生的枚举类型保存
+func IsNativeEnum(d core.Dialect, col *core.Column) bool {
+	_, ok := d.(EnumHooker)
+	return ok && len(col.Enums) > 0 && col.PrimitiveType == core.String
+}
+
+// EnumCheckName 以 CHECK 约束实现枚举时的约束名
+//
+// table 为表名，如果包含了模式名，会被忽略；col 为列名。
+func EnumCheckName(table, col string) string {
+	if index := strings.LastIndexByte(table, '.'); index >= 0 {
+		table = table[index+1:]
+	}
+	return table + "_" + col + "_enum"
+}
+
+// EnumCheckExpr 生成限定列 col 取值范围的 CHECK 表达式
+//
+// 格式为 col IN (v1,v2)，其中的值由 [core.Dialect.Literal] 转换。
+func EnumCheckExpr(d core.Dialect, col *core.Column) (string, error) {
+	w := core.NewBuilder("").QuoteKey(col.Name).WString(" IN(")
+	if err := EnumValues(d, w, col); err != nil {
+		return "", err
+	}
+	return w.WBytes(')').String()
+}
+
+// EnumValues 将 col.Enums 以逗号分隔写入 w
+func EnumValues(d core.Dialect, w *core.Builder, col *core.Column) error {
+	for _, e := range col.Enums {
+		v, err := core.EnumValue(e)
+		if err != nil {
+			return err
+		}
+
+		l, err := d.Literal(v)
+		if err != nil {
+			return err
+		}
+		w.WString(l).WBytes(',')
+	}
+	w.TruncateLast(1)
+	return nil
+}
+
+// 返回列 col 在表 table 中的类型
+//
+// 对于原生的枚举类型，ddl 为需要在创建列之前执行的语句。
+func columnType(d core.Dialect, table string, col *core.Column) (typ string, ddl []string, err error) {
+	if IsNativeEnum(d, col) {
+		return d.(EnumHooker).EnumSQLType(table, col)
+	}
+
+	typ, err = d.SQLType(col)
+	return typ, nil, err
+}
+
+// AlterEnum 声明修改枚举列的语句
+func (sql *SQLBuilder) AlterEnum() *AlterEnumStmt { return AlterEnum(sql.engine) }
+
+// AlterEnum 声明修改枚举列的语句
+//
+// 原生的枚举类型由 [EnumHooker.AlterEnumSQL] 生成语句，
+// 其它情况则重建由 [EnumCheckName] 命名的 CHECK 约束。
+func AlterEnum(e core.Engine) *AlterEnumStmt {
+	stmt := &AlterEnumStmt{}
+	stmt.ddlStmt = newDDLStmt(e, stmt)
+	return stmt
+}
+
+// Table 指定表名
+//
+// 重复指定，会覆盖之前的。
+func (stmt *AlterEnumStmt) Table(table string) *AlterEnumStmt {
+	stmt.TableName = table
+	return stmt
+}
+
+// Column 指定需要修改的列
+//
+// col.Enums 为修改后的可选值。
+func (stmt *AlterEnumStmt) Column(col *core.Column) *AlterEnumStmt {
+	stmt.Col = col
+	return stmt
+}
+
+// DDLSQL 生成 SQL 语句
+func (stmt *AlterEnumStmt) DDLSQL() ([]string, error) {
+	if stmt.err != nil {
+		return nil, stmt.Err()
+	}
+
+	if stmt.TableName == "" {
+		return nil, SyntaxError("ALTER ENUM", "未指定表名")
+	}
+
+	if stmt.Col == nil {
+		return nil, SyntaxError("ALTER ENUM", "未指定列")
+	}
+
+	if len(stmt.Col.Enums) == 0 {
+		return nil, SyntaxError("ALTER ENUM", "未指定枚举值")
+	}
+
+	if err := stmt.Col.Check(); err != nil {
+		return nil, err
+	}
+
+	if IsNativeEnum(stmt.Dialect(), stmt.Col) {
+		return stmt.Dialect().(EnumHooker).AlterEnumSQL(stmt.TableName, stmt.Col)
+	}
+
+	if hook, ok := stmt.Dialect().(AlterEnumStmtHooker); ok {
+		return hook.AlterEnumStmtHook(stmt)
+	}
+
+	expr, err := EnumCheckExpr(stmt.Dialect(), stmt.Col)
+	if err != nil {
+		return nil, err
+	}
+	name := EnumCheckName(stmt.TableName, stmt.Col.Name)
+
+	drop, err := DropConstraint(stmt.Engine()).Table(stmt.TableName).Constraint(name).DDLSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	add, err := AddConstraint(stmt.Engine()).Table(stmt.TableName).Check(name, expr).DDLSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(drop, add...), nil
+}
+
+// Reset 重置
+func (stmt *AlterEnumStmt) Reset() *AlterEnumStmt {
+	stmt.baseStmt.Reset()
+	stmt.TableName = ""
+	stmt.Col = nil
+	return stmt
+}
@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package expr
+
+import "github.com/issue9/orm/v6/core"
+
+// Col 类型为 T 的值表达式
+//
+// 可以是列、值或是函数等，T 仅用于在编译期约束与之比较或是运算的值类型。
+type Col[T any] struct {
+	w writer
+}
+
+// Column 声明类型为 T 的列
+//
+// name 为列名，可以是 col 或是 table.col 的形式，都会自动添加引号。
+func Column[T any](name string) Col[T] {
+	return Col[T]{w: func(b *core.Builder) ([]any, error) {
+		b.QuoteColumn(name)
+		return nil, nil
+	}}
+}
+
+// Value 将 v 作为参数值声明为表达式
+func Value[T any](v T) Col[T] {
+	return Col[T]{w: func(b *core.Builder) ([]any, error) {
+		return writeValue(b, v), nil
+	}}
+}
+
+// Raw 原始的 SQL 表达式
+//
+// query 中的关键字需要自行使用 {} 包含。
+func Raw[T any](query string, args ...any) Col[T] {
+	return Col[T]{w: func(b *core.Builder) ([]any, error) {
+		b.WString(query)
+		return args, nil
+	}}
+}
+
+func (c Col[T]) write(b *core.Builder) ([]any, error) { return c.w(b) }
+
+// SQL 生成 SQL 语句及其参数
+func (c Col[T]) SQL() (string, []any, error) { return buildSQL(c.w) }
+
+// As 指定别名
+func (c Col[T]) As(alias string) Expr { return As(c, alias) }
+
+func (c Col[T]) compare(op string, v any) Cond {
+	return Cond{w: func(b *core.Builder) ([]any, error) {
+		args, err := c.w(b)
+		if err != nil {
+			return nil, err
+		}
+		b.WString(op)
+		return append(args, writeValue(b, v)...), nil
+	}}
+}
+
+func (c Col[T]) compareCol(op string, o Col[T]) Cond {
+	return Cond{w: func(b *core.Builder) ([]any, error) {
+		return writeList(b, op, c, o)
+	}}
+}
+
+// Eq 等于 v
+func (c Col[T]) Eq(v T) Cond { return c.compare("=", v) }
+
+// Ne 不等于 v
+func (c Col[T]) Ne(v T) Cond { return c.compare("<>", v) }
+
+// Gt 大于 v
+func (c Col[T]) Gt(v T) Cond { return c.compare(">", v) }
+
+// Ge 大于等于 v
+func (c Col[T]) Ge(v T) Cond { return c.compare(">=", v) }
+
+// Lt 小于 v
+func (c Col[T]) Lt(v T) Cond { return c.compare("<", v) }
+
+// Le 小于等于 v
+func (c Col[T]) Le(v T) Cond { return c.compare("<=", v) }
+
+// EqCol 等于表达式 o
+//
+// 一般用于 JOIN 的关联条件。
+func (c Col[T]) EqCol(o Col[T]) Cond { return c.compareCol("=", o) }
+
+// NeCol 不等于表达式 o
+func (c Col[T]) NeCol(o Col[T]) Cond { return c.compareCol("<>", o) }
+
+// GtCol 大于表达式 o
+func (c Col[T]) GtCol(o Col[T]) Cond { return c.compareCol(">", o) }
+
+// GeCol 大于等于表达式 o
+func (c Col[T]) GeCol(o Col[T]) Cond { return c.compareCol(">=", o) }
+
+// LtCol 小于表达式 o
+func (c Col[T]) LtCol(o Col[T]) Cond { return c.compareCol("<", o) }
+
+// LeCol 小于等于表达式 o
+func (c Col[T]) LeCol(o Col[T]) Cond { return c.compareCol("<=", o) }
+
+// In 生成 col IN(v...)
+//
+// v 为空时，生成恒为假的条件。
+func (c Col[T]) In(v ...T) Cond { return c.in(false, v) }
+
+// NotIn 生成 col NOT IN(v...)
+//
+// v 为空时，生成恒为真的条件。
+func (c Col[T]) NotIn(v ...T) Cond { return c.in(true, v) }
+
+func (c Col[T]) in(not bool, v []T) Cond {
+	return Cond{w: func(b *core.Builder) ([]any, error) {
+		if len(v) == 0 {
+			if not {
+				b.WString("1=1")
+			} else {
+				b.WString("1=0")
+			}
+			return nil, nil
+		}
+
+		args, err := c.w(b)
+		if err != nil {
+			return nil, err
+		}
+
+		if not {
+			b.WString(" NOT")
+		}
+		b.WString(" IN(")
+		for _, vv := range v {
+			args = append(args, writeValue(b, vv)...)
+			b.WBytes(',')
+		}
+		b.TruncateLast(1).WBytes(')')
+
+		return args, nil
+	}}
+}
+
+// Like 生成 col LIKE pattern
+func (c Col[T]) Like(pattern string) Cond { return c.compare(" LIKE ", pattern) }
+
+// NotLike 生成 col NOT LIKE pattern
+func (c Col[T]) NotLike(pattern string) Cond { return c.compare(" NOT LIKE ", pattern) }
+
+// Between 生成 col BETWEEN v1 AND v2
+func (c Col[T]) Between(v1, v2 T) Cond {
+	return Cond{w: func(b *core.Builder) ([]any, error) {
+		args, err := c.w(b)
+		if err != nil {
+			return nil, err
+		}
+		b.WString(" BETWEEN ")
+		args = append(args, writeValue(b, v1)...)
+		b.WString(" AND ")
+		return append(args, writeValue(b, v2)...), nil
+	}}
+}
+
+// IsNull 生成 col IS NULL
+func (c Col[T]) IsNull() Cond { return c.suffix(" IS NULL") }
+
+// IsNotNull 生成 col IS NOT NULL
+func (c Col[T]) IsNotNull() Cond { return c.suffix(" IS NOT NULL") }
+
+func (c Col[T]) suffix(s string) Cond {
+	return Cond{w: func(b *core.Builder) ([]any, error) {
+		args, err := c.w(b)
+		if err != nil {
+			return nil, err
+		}
+		b.WString(s)
+		return args, nil
+	}}
+}
+
+func (c Col[T]) arithmetic(op byte, o Col[T]) Col[T] {
+	return Col[T]{w: func(b *core.Builder) ([]any, error) {
+		b.WBytes('(')
+		args, err := writeList(b, string(op), c, o)
+		if err != nil {
+			return nil, err
+		}
+		b.WBytes(')')
+		return args, nil
+	}}
+}
+
+// Add 生成 (col+v)
+func (c Col[T]) Add(v T) Col[T] { return c.arithmetic('+', Value(v)) }
+
+// Sub 生成 (col-v)
+func (c Col[T]) Sub(v T) Col[T] { return c.arithmetic('-', Value(v)) }
+
+// Mul 生成 (col*v)
+func (c Col[T]) Mul(v T) Col[T] { return c.arithmetic('*', Value(v)) }
+
+// Div 生成 (col/v)
+func (c Col[T]) Div(v T) Col[T] { return c.arithmetic('/', Value(v)) }
+
+// AddCol 生成 (col+o)
+func (c Col[T]) AddCol(o Col[T]) Col[T] { return c.arithmetic('+', o) }
+
+// SubCol 生成 (col-o)
+func (c Col[T]) SubCol(o Col[T]) Col[T] { return c.arithmetic('-', o) }
+
+// MulCol 生成 (col*o)
+func (c Col[T]) MulCol(o Col[T]) Col[T] { return c.arithmetic('*', o) }
+
+// DivCol 生成 (col/o)
+func (c Col[T]) DivCol(o Col[T]) Col[T] { return c.arithmetic('/', o) }
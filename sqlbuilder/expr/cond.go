@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package expr
+
+import "github.com/issue9/orm/v6/core"
+
+// Cond 条件表达式
+//
+// 可用于 WHERE、JOIN 的关联条件以及 CASE WHEN 等需要布尔值的地方。
+type Cond struct {
+	w writer
+}
+
+// RawCond 原始的 SQL 条件表达式
+//
+// query 中的关键字需要自行使用 {} 包含。
+func RawCond(query string, args ...any) Cond {
+	return Cond{w: func(b *core.Builder) ([]any, error) {
+		b.WString(query)
+		return args, nil
+	}}
+}
+
+func (c Cond) write(b *core.Builder) ([]any, error) { return c.w(b) }
+
+// SQL 生成 SQL 语句及其参数
+func (c Cond) SQL() (string, []any, error) { return buildSQL(c.w) }
+
+// And 生成 (c AND conds[0] AND conds[1]...)
+func (c Cond) And(conds ...Cond) Cond { return And(append([]Cond{c}, conds...)...) }
+
+// Or 生成 (c OR conds[0] OR conds[1]...)
+func (c Cond) Or(conds ...Cond) Cond { return Or(append([]Cond{c}, conds...)...) }
+
+// And 以 AND 连接所有的条件
+//
+// conds 为空时，生成恒为真的条件。
+func And(conds ...Cond) Cond { return join(" AND ", "1=1", conds) }
+
+// Or 以 OR 连接所有的条件
+//
+// conds 为空时，生成恒为假的条件。
+func Or(conds ...Cond) Cond { return join(" OR ", "1=0", conds) }
+
+func join(sep, empty string, conds []Cond) Cond {
+	switch len(conds) {
+	case 0:
+		return RawCond(empty)
+	case 1:
+		return conds[0]
+	}
+
+	exprs := make([]Expr, 0, len(conds))
+	for _, c := range conds {
+		exprs = append(exprs, c)
+	}
+
+	return Cond{w: func(b *core.Builder) ([]any, error) {
+		b.WBytes('(')
+		args, err := writeList(b, sep, exprs...)
+		if err != nil {
+			return nil, err
+		}
+		b.WBytes(')')
+		return args, nil
+	}}
+}
+
+// Not 生成 NOT (c)
+func Not(c Cond) Cond {
+	return Cond{w: func(b *core.Builder) ([]any, error) {
+		b.WString("NOT (")
+		args, err := c.w(b)
+		if err != nil {
+			return nil, err
+		}
+		b.WBytes(')')
+		return args, nil
+	}}
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+// Package expr 提供类型安全的 SQL 表达式
+//
+// 生成的表达式实现了 [sqlbuilder.SQLer] 接口，
+// 可以通过 [sqlbuilder.WhereStmt.AndExpr]、[sqlbuilder.SelectStmt.ColumnExpr]
+// 和 [sqlbuilder.UpdateStmt.SetExpr] 等方法与 sqlbuilder 结合使用：
+//
+//	age := expr.Column[int]("age")
+//	name := expr.Column[string]("name")
+//
+//	sqlbuilder.Select(db).
+//	    ColumnExpr(expr.Lower(name).As("n")).
+//	    From("users").
+//	    AndExpr(age.Gt(18).Or(name.Like("a%")))
+//
+// 表达式中的列名会自动添加引号，值则以参数的形式传递，
+// 如果值的类型为 [sql.NamedArg]，则会以命名参数的形式写入。
+//
+// [sqlbuilder.SQLer]: https://pkg.go.dev/github.com/issue9/orm/v6/sqlbuilder#SQLer
+// [sqlbuilder.WhereStmt.AndExpr]: https://pkg.go.dev/github.com/issue9/orm/v6/sqlbuilder#WhereStmt.AndExpr
+// [sqlbuilder.SelectStmt.ColumnExpr]: https://pkg.go.dev/github.com/issue9/orm/v6/sqlbuilder#SelectStmt.ColumnExpr
+// [sqlbuilder.UpdateStmt.SetExpr]: https://pkg.go.dev/github.com/issue9/orm/v6/sqlbuilder#UpdateStmt.SetExpr
+package expr
+
+import (
+	"database/sql"
+
+	"github.com/issue9/orm/v6/core"
+)
+
+// Expr 表达式的基本接口
+//
+// 仅由当前包中的类型实现。
+type Expr interface {
+	// SQL 生成 SQL 语句及其参数
+	SQL() (string, []any, error)
+
+	write(b *core.Builder) ([]any, error)
+}
+
+// 将表达式写入 b，并返回其中的参数。
+type writer func(b *core.Builder) ([]any, error)
+
+type aliasExpr struct {
+	e     Expr
+	alias string
+}
+
+func buildSQL(w writer) (string, []any, error) {
+	b := core.NewBuilder("")
+	args, err := w(b)
+	if err != nil {
+		return "", nil, err
+	}
+
+	query, err := b.String()
+	if err != nil {
+		return "", nil, err
+	}
+	return query, args, nil
+}
+
+func writeValue(b *core.Builder, v any) []any {
+	if named, ok := v.(sql.NamedArg); ok && named.Name != "" {
+		b.WBytes('@').WString(named.Name)
+	} else {
+		b.WBytes('?')
+	}
+	return []any{v}
+}
+
+// 依次写入 exprs，之间以 sep 分隔。
+func writeList(b *core.Builder, sep string, exprs ...Expr) ([]any, error) {
+	args := make([]any, 0, len(exprs))
+	for i, e := range exprs {
+		if i > 0 {
+			b.WString(sep)
+		}
+
+		a, err := e.write(b)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, a...)
+	}
+	return args, nil
+}
+
+// As 为表达式指定别名
+//
+// 一般用于 SELECT 中的列。
+func As(e Expr, alias string) Expr { return &aliasExpr{e: e, alias: alias} }
+
+func (a *aliasExpr) write(b *core.Builder) ([]any, error) {
+	args, err := a.e.write(b)
+	if err != nil {
+		return nil, err
+	}
+	b.WString(" AS ").QuoteKey(a.alias)
+	return args, nil
+}
+
+func (a *aliasExpr) SQL() (string, []any, error) { return buildSQL(a.write) }
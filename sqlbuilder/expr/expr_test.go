@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package expr_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+
+	"github.com/issue9/orm/v6/internal/sqltest"
+	"github.com/issue9/orm/v6/sqlbuilder"
+	"github.com/issue9/orm/v6/sqlbuilder/expr"
+)
+
+var (
+	_ sqlbuilder.SQLer = expr.Col[int]{}
+	_ sqlbuilder.SQLer = expr.Cond{}
+	_ expr.Expr        = expr.Column[int]("id").As("x")
+)
+
+func TestCol(t *testing.T) {
+	a := assert.New(t, false)
+	id := expr.Column[int64]("u.id")
+	name := expr.Column[string]("name")
+
+	data := []*struct {
+		e     sqlbuilder.SQLer
+		query string
+		args  []any
+	}{
+		{e: id, query: "{u}.{id}"},
+		{e: id.As("uid"), query: "{u}.{id} AS {uid}"},
+		{e: id.Eq(1), query: "{u}.{id}=?", args: []any{int64(1)}},
+		{e: id.Ne(1), query: "{u}.{id}<>?", args: []any{int64(1)}},
+		{e: id.Gt(1), query: "{u}.{id}>?", args: []any{int64(1)}},
+		{e: id.Ge(1), query: "{u}.{id}>=?", args: []any{int64(1)}},
+		{e: id.Lt(1), query: "{u}.{id}<?", args: []any{int64(1)}},
+		{e: id.Le(1), query: "{u}.{id}<=?", args: []any{int64(1)}},
+		{e: id.EqCol(expr.Column[int64]("i.uid")), query: "{u}.{id}={i}.{uid}"},
+		{e: id.In(1, 2), query: "{u}.{id} IN(?,?)", args: []any{int64(1), int64(2)}},
+		{e: id.NotIn(1), query: "{u}.{id} NOT IN(?)", args: []any{int64(1)}},
+		{e: id.In(), query: "1=0"},
+		{e: id.NotIn(), query: "1=1"},
+		{e: id.Between(1, 5), query: "{u}.{id} BETWEEN ? AND ?", args: []any{int64(1), int64(5)}},
+		{e: name.Like("a%"), query: "{name} LIKE ?", args: []any{"a%"}},
+		{e: name.NotLike("a%"), query: "{name} NOT LIKE ?", args: []any{"a%"}},
+		{e: name.IsNull(), query: "{name} IS NULL"},
+		{e: name.IsNotNull(), query: "{name} IS NOT NULL"},
+		{e: id.Add(1).Mul(2), query: "(({u}.{id}+?)*?)", args: []any{int64(1), int64(2)}},
+		{e: id.SubCol(expr.Column[int64]("age")).Gt(0), query: "({u}.{id}-{age})>?", args: []any{int64(0)}},
+		{e: expr.Column[any]("name").Eq(sql.Named("n", "x")), query: "{name}=@n", args: []any{sql.Named("n", "x")}},
+	}
+
+	for _, item := range data {
+		query, args, err := item.e.SQL()
+		a.NotError(err)
+		sqltest.Equal(a, query, item.query)
+		a.Equal(args, item.args, "%s", item.query)
+	}
+}
+
+func TestCond(t *testing.T) {
+	a := assert.New(t, false)
+	id := expr.Column[int]("id")
+	name := expr.Column[string]("name")
+
+	query, args, err := id.Gt(1).And(name.IsNotNull(), id.Lt(5)).SQL()
+	a.NotError(err).Equal(args, []any{1, 5})
+	sqltest.Equal(a, query, "({id}>? AND {name} IS NOT NULL AND {id}<?)")
+
+	query, args, err = expr.Or(id.Eq(1), expr.Not(name.Eq("n"))).SQL()
+	a.NotError(err).Equal(args, []any{1, "n"})
+	sqltest.Equal(a, query, "({id}=? OR NOT ({name}=?))")
+
+	query, args, err = expr.And().SQL()
+	a.NotError(err).Empty(args)
+	sqltest.Equal(a, query, "1=1")
+
+	query, args, err = expr.Or(expr.RawCond("{id}>?", 3)).SQL()
+	a.NotError(err).Equal(args, []any{3})
+	sqltest.Equal(a, query, "{id}>?")
+}
+
+func TestFunc(t *testing.T) {
+	a := assert.New(t, false)
+	age := expr.Column[int]("age")
+	name := expr.Column[string]("name")
+
+	data := []*struct {
+		e     sqlbuilder.SQLer
+		query string
+		args  []any
+	}{
+		{e: expr.Lower(name).Eq("a"), query: "LOWER({name})=?", args: []any{"a"}},
+		{e: expr.Upper(name), query: "UPPER({name})"},
+		{e: expr.Coalesce(age, expr.Value(0)), query: "COALESCE({age},?)", args: []any{0}},
+		{e: expr.Count(age).As("cnt"), query: "COUNT({age}) AS {cnt}"},
+		{e: expr.CountAll().Gt(1), query: "COUNT(*)>?", args: []any{int64(1)}},
+		{e: expr.Sum(age), query: "SUM({age})"},
+		{e: expr.Max(age), query: "MAX({age})"},
+		{e: expr.Min(age), query: "MIN({age})"},
+		{e: expr.Avg(age), query: "AVG({age})"},
+		{e: expr.Func[string]("CONCAT", name, expr.Value("-")), query: "CONCAT({name},?)", args: []any{"-"}},
+		{
+			e:     expr.Case[string]().When(age.Lt(18), "child").WhenCol(age.IsNull(), name).Else("adult").End().As("t"),
+			query: "CASE WHEN {age}<? THEN ? WHEN {age} IS NULL THEN {name} ELSE ? END AS {t}",
+			args:  []any{18, "child", "adult"},
+		},
+	}
+
+	for _, item := range data {
+		query, args, err := item.e.SQL()
+		a.NotError(err)
+		sqltest.Equal(a, query, item.query)
+		a.Equal(args, item.args, "%s", item.query)
+	}
+
+	query, args, err := expr.Case[int]().Else(1).End().SQL()
+	a.Error(err).Empty(query).Nil(args)
+}
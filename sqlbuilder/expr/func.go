@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package expr
+
+import (
+	"errors"
+
+	"github.com/issue9/orm/v6/core"
+)
+
+// CaseExpr CASE WHEN 表达式
+type CaseExpr[T any] struct {
+	whens []Cond
+	thens []Col[T]
+	els   *Col[T]
+}
+
+// Func 声明 SQL 函数
+//
+// name 为函数名，args 为函数的参数，生成 name(args[0],args[1]...)。
+func Func[T any](name string, args ...Expr) Col[T] {
+	return Col[T]{w: func(b *core.Builder) ([]any, error) {
+		b.WString(name).WBytes('(')
+		a, err := writeList(b, ",", args...)
+		if err != nil {
+			return nil, err
+		}
+		b.WBytes(')')
+		return a, nil
+	}}
+}
+
+// Lower 生成 LOWER(c)
+func Lower(c Col[string]) Col[string] { return Func[string]("LOWER", c) }
+
+// Upper 生成 UPPER(c)
+func Upper(c Col[string]) Col[string] { return Func[string]("UPPER", c) }
+
+// Coalesce 生成 COALESCE(c[0],c[1]...)
+//
+// 如果需要指定默认值，可以通过 [Value] 将值转换成表达式：
+//
+//	Coalesce(Column[int]("age"), Value(0))
+func Coalesce[T any](c ...Col[T]) Col[T] { return Func[T]("COALESCE", cols(c)...) }
+
+// Count 生成 COUNT(c)
+func Count[T any](c Col[T]) Col[int64] { return Func[int64]("COUNT", c) }
+
+// CountAll 生成 COUNT(*)
+func CountAll() Col[int64] { return Raw[int64]("COUNT(*)") }
+
+// Sum 生成 SUM(c)
+func Sum[T any](c Col[T]) Col[T] { return Func[T]("SUM", c) }
+
+// Max 生成 MAX(c)
+func Max[T any](c Col[T]) Col[T] { return Func[T]("MAX", c) }
+
+// Min 生成 MIN(c)
+func Min[T any](c Col[T]) Col[T] { return Func[T]("MIN", c) }
+
+// Avg 生成 AVG(c)
+func Avg[T any](c Col[T]) Col[float64] { return Func[float64]("AVG", c) }
+
+func cols[T any](c []Col[T]) []Expr {
+	exprs := make([]Expr, 0, len(c))
+	for _, cc := range c {
+		exprs = append(exprs, cc)
+	}
+	return exprs
+}
+
+// Case 声明 CASE WHEN 表达式
+//
+//	Case[string]().
+//	    When(age.Lt(18), "child").
+//	    Else("adult").
+//	    End()
+func Case[T any]() *CaseExpr[T] { return &CaseExpr[T]{} }
+
+// When 添加 WHEN cond THEN v
+func (c *CaseExpr[T]) When(cond Cond, v T) *CaseExpr[T] { return c.WhenCol(cond, Value(v)) }
+
+// WhenCol 添加 WHEN cond THEN v
+//
+// 与 [CaseExpr.When] 的区别在于 v 可以是任意表达式。
+func (c *CaseExpr[T]) WhenCol(cond Cond, v Col[T]) *CaseExpr[T] {
+	c.whens = append(c.whens, cond)
+	c.thens = append(c.thens, v)
+	return c
+}
+
+// Else 指定 ELSE v
+func (c *CaseExpr[T]) Else(v T) *CaseExpr[T] { return c.ElseCol(Value(v)) }
+
+// ElseCol 指定 ELSE v
+//
+// 与 [CaseExpr.Else] 的区别在于 v 可以是任意表达式。
+func (c *CaseExpr[T]) ElseCol(v Col[T]) *CaseExpr[T] {
+	c.els = &v
+	return c
+}
+
+// End 结束 CASE 表达式并返回
+func (c *CaseExpr[T]) End() Col[T] {
+	return Col[T]{w: func(b *core.Builder) ([]any, error) {
+		if len(c.whens) == 0 {
+			return nil, errors.New("CASE 表达式至少需要一个 WHEN 子句")
+		}
+
+		args := make([]any, 0, len(c.whens)*2+1)
+		b.WString("CASE")
+		for i, w := range c.whens {
+			b.WString(" WHEN ")
+			a, err := w.write(b)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, a...)
+
+			b.WString(" THEN ")
+			if a, err = c.thens[i].write(b); err != nil {
+				return nil, err
+			}
+			args = append(args, a...)
+		}
+
+		if c.els != nil {
+			b.WString(" ELSE ")
+			a, err := c.els.write(b)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, a...)
+		}
+		b.WString(" END")
+
+		return args, nil
+	}}
+}
@@ -85,7 +85,7 @@ func (stmt *CreateIndexStmt) DDLSQL() ([]string, error) {
 
 	builder.WString(stmt.name).
 		WString(" ON ").
-		QuoteKey(stmt.table).
+		QuoteTable(stmt.table).
 		WBytes('(')
 	for _, col := range stmt.cols {
 		builder.QuoteKey(col).
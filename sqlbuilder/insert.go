@@ -100,6 +100,17 @@ func (stmt *InsertStmt) Reset() *InsertStmt {
 	return stmt
 }
 
+// ReturningIntoHooker 通过输出参数获取自增值的钩子函数
+//
+// 像 oracle 等数据库，只能通过 RETURNING ... INTO 将自增值写入输出参数，
+// 无法由 [core.Dialect.LastInsertIDSQL] 实现。
+type ReturningIntoHooker interface {
+	// ReturningIntoHook 返回添加在插入语句之后的语句片段以及对应的输出参数
+	//
+	// 语句片段中的输出参数以 ? 表示，执行之后自增值会被写入 dest。
+	ReturningIntoHook(col string, dest *int64) (string, any)
+}
+
 // InsertDefaultValueHooker 插入值全部为默认值时的钩子处理函数
 type InsertDefaultValueHooker interface {
 	InsertDefaultValueHook(tableName string) (string, []any, error)
@@ -115,7 +126,7 @@ func (stmt *InsertStmt) SQL() (string, []any, error) {
 		return "", nil, SyntaxError("INSERT", "未指定表名")
 	}
 
-	builder := core.NewBuilder("INSERT INTO ").QuoteKey(stmt.table)
+	builder := core.NewBuilder("INSERT INTO ").QuoteTable(stmt.table)
 
 	if stmt.selectStmt != nil {
 		return stmt.fromSelect(builder)
@@ -219,6 +230,19 @@ func (stmt *InsertStmt) LastInsertIDContext(ctx context.Context, col string) (id
 		return 0, errors.New("多行插入语句，无法获取 LastInsertIDContext")
 	}
 
+	if hook, ok := stmt.Dialect().(ReturningIntoHooker); ok {
+		query, args, err := stmt.SQL()
+		if err != nil {
+			return 0, err
+		}
+
+		q, out := hook.ReturningIntoHook(col, &id)
+		if _, err = stmt.engine.ExecContext(ctx, query+q, append(args, out)...); err != nil {
+			return 0, err
+		}
+		return id, nil
+	}
+
 	q, apd := stmt.Dialect().LastInsertIDSQL(stmt.table, col)
 	if q == "" {
 		rslt, err := stmt.ExecContext(ctx)
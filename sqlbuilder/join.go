@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package sqlbuilder
+
+import "github.com/issue9/orm/v6/core"
+
+// 多表 UPDATE 和 DELETE 的语法
+const (
+	JoinSubquery JoinSyntax = iota // 以关联子查询实现，所有数据库都支持
+	JoinMysql                      // UPDATE t JOIN ... SET 和 DELETE t FROM t JOIN ...
+	JoinFrom                       // UPDATE t SET ... FROM 和 DELETE FROM t USING ...
+)
+
+// JoinSyntax 多表 UPDATE 和 DELETE 所采用的语法
+type JoinSyntax int8
+
+// JoinSyntaxHooker 多表 UPDATE 和 DELETE 语句的钩子函数
+//
+// 未实现此接口的数据库，均采用 [JoinSubquery] 语法。
+// 参数 e 为执行语句的实例，可用于查询服务端的版本等信息。
+type JoinSyntaxHooker interface {
+	// UpdateJoinSyntax 多表 UPDATE 所采用的语法
+	UpdateJoinSyntax(e core.Engine) JoinSyntax
+
+	// DeleteJoinSyntax 多表 DELETE 所采用的语法
+	DeleteJoinSyntax(e core.Engine) JoinSyntax
+}
+
+// 多表操作中的关联表，仅支持 INNER JOIN。
+type tableJoins []*tableJoin
+
+type tableJoin struct {
+	table string
+	alias string
+	on    string
+}
+
+func (j *tableJoins) add(table, alias, on string) error {
+	if table == "" {
+		return SyntaxError("JOIN", "未指定表名")
+	}
+
+	if on == "" {
+		return SyntaxError("JOIN", "未指定关联条件")
+	}
+
+	*j = append(*j, &tableJoin{table: table, alias: alias, on: on})
+	return nil
+}
+
+func (j *tableJoins) reset() { *j = (*j)[:0] }
+
+func (j tableJoins) writeTable(b *core.Builder, t *tableJoin) {
+	b.QuoteTable(t.table)
+	if t.alias != "" {
+		b.WString(" AS ").QuoteKey(t.alias)
+	}
+}
+
+// 写入 INNER JOIN t1 AS a1 ON ... INNER JOIN t2 AS a2 ON ...
+func (j tableJoins) writeJoins(b *core.Builder) {
+	for _, t := range j {
+		b.WString(" INNER JOIN ")
+		j.writeTable(b, t)
+		b.WString(" ON ").WString(t.on)
+	}
+}
+
+// 写入 t1 AS a1,t2 AS a2
+func (j tableJoins) writeTables(b *core.Builder) {
+	for _, t := range j {
+		j.writeTable(b, t)
+		b.WBytes(',')
+	}
+	b.TruncateLast(1)
+}
+
+// 写入所有关联条件以及 where 条件
+//
+// where 为空时，仅写入关联条件。
+func (j tableJoins) writeConds(b *core.Builder, where string) {
+	for _, t := range j {
+		b.WBytes('(').WString(t.on).WString(") AND ")
+	}
+	b.TruncateLast(len(" AND "))
+
+	if where != "" {
+		b.WString(" AND (").WString(where).WBytes(')')
+	}
+}
+
+// 写入 (where) AND EXISTS(SELECT 1 FROM ... WHERE ...)
+//
+// where 作用于被操作的表，保持在子查询之外，子查询中仅包含关联条件。
+func (j tableJoins) writeExists(b *core.Builder, where string) {
+	if where != "" {
+		b.WBytes('(').WString(where).WString(") AND ")
+	}
+
+	b.WString("EXISTS(SELECT 1 FROM ")
+	j.writeTables(b)
+	b.WString(" WHERE ")
+	j.writeConds(b, "")
+	b.WBytes(')')
+}
+
+func joinSyntax(e core.Engine, update bool) JoinSyntax {
+	hook, ok := e.Dialect().(JoinSyntaxHooker)
+	switch {
+	case !ok:
+		return JoinSubquery
+	case update:
+		return hook.UpdateJoinSyntax(e)
+	default:
+		return hook.DeleteJoinSyntax(e)
+	}
+}
+
+// Join 关联其它表
+//
+// 仅支持 INNER JOIN，关联的表仅用于筛选需要更新的数据。
+// table 为关联的表名；alias 为别名，可以为空；
+// on 为关联条件，其中的关键字需要自行使用 {} 包含，
+// 如果需要引用被更新的表，应该使用 [UpdateStmt.Table] 指定的表名。
+//
+// 根据数据库的不同，会生成以下不同的语句：
+//   - mysql 和 mariadb 为 UPDATE t INNER JOIN ... SET ...；
+//   - postgres 和 sqlite3>=3.33 为 UPDATE t SET ... FROM ...；
+//   - 其它数据库采用 UPDATE t SET ... WHERE (...) AND EXISTS(SELECT ...) 的形式，
+//     此时 WHERE 条件位于子查询之外，无法引用关联的表，对关联表的筛选应该写在 on 中；
+func (stmt *UpdateStmt) Join(table, alias, on string) *UpdateStmt {
+	if stmt.err == nil {
+		stmt.err = stmt.joins.add(table, alias, on)
+	}
+	return stmt
+}
+
+// Join 关联其它表
+//
+// 参数可参考 [UpdateStmt.Join]。
+//
+// 根据数据库的不同，会生成以下不同的语句：
+//   - mysql 和 mariadb 为 DELETE t FROM t INNER JOIN ...；
+//   - postgres 为 DELETE FROM t USING ...；
+//   - 其它数据库采用 DELETE FROM t WHERE (...) AND EXISTS(SELECT ...) 的形式，
+//     此时 WHERE 条件位于子查询之外，无法引用关联的表，对关联表的筛选应该写在 on 中；
+func (stmt *DeleteStmt) Join(table, alias, on string) *DeleteStmt {
+	if stmt.err == nil {
+		stmt.err = stmt.joins.add(table, alias, on)
+	}
+	return stmt
+}
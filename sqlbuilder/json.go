@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package sqlbuilder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/issue9/orm/v6/core"
+)
+
+// JSONHooker 对 JSON 数据的查询
+//
+// 未实现此接口的数据库在调用 JSON 相关的方法时会返回错误。
+type JSONHooker interface {
+	// JSONExtractSQL 返回从列 col 中提取 path 所指定值的表达式
+	//
+	// path 为各层级的键名，可以转换成非负整数的表示数组的下标，path 至少有一个元素；
+	// 表达式的值应该为文本类型，args 为表达式中占位符对应的参数。
+	JSONExtractSQL(col string, path ...string) (expr string, args []any, err error)
+
+	// JSONContainsSQL 返回列 col 是否包含指定 JSON 文档的条件表达式
+	//
+	// 表达式中应该有且仅有一个 ? 占位符，表示被包含的 JSON 文档。
+	JSONContainsSQL(col string) (string, error)
+}
+
+func jsonHooker(e core.Engine) (JSONHooker, error) {
+	if e == nil {
+		return nil, errors.New("未指定数据库，无法生成 JSON 表达式")
+	}
+
+	if hook, ok := e.Dialect().(JSONHooker); ok {
+		return hook, nil
+	}
+	return nil, fmt.Errorf("%s 不支持 JSON 查询", e.Dialect().Name())
+}
+
+func jsonExtract(e core.Engine, col string, path []string) (string, []any, error) {
+	if len(path) == 0 {
+		return "", nil, errors.New("参数 path 不能为空")
+	}
+
+	hook, err := jsonHooker(e)
+	if err != nil {
+		return "", nil, err
+	}
+	return hook.JSONExtractSQL(col, path...)
+}
+
+// AndJSON 指定 WHERE ... AND <col 中 path 的值> op ?
+//
+// path 为 JSON 中各层级的键名，数组下标以数值表示，提取的值以文本的形式参与比较。
+// 仅 [SQLBuilder.Where] 或是各语句自带的 WHERE 可用，由 [Where] 创建的实例将返回错误。
+func (stmt *WhereStmt) AndJSON(col, op string, v any, path ...string) *WhereStmt {
+	return stmt.json(true, col, op, v, path)
+}
+
+// OrJSON 指定 WHERE ... OR <col 中 path 的值> op ?
+//
+// 具体可参考 [WhereStmt.AndJSON]。
+func (stmt *WhereStmt) OrJSON(col, op string, v any, path ...string) *WhereStmt {
+	return stmt.json(false, col, op, v, path)
+}
+
+func (stmt *WhereStmt) json(and bool, col, op string, v any, path []string) *WhereStmt {
+	if stmt.err != nil {
+		return stmt
+	}
+
+	query, args, err := jsonExtract(stmt.engine, col, path)
+	if err != nil {
+		stmt.err = err
+		return stmt
+	}
+	return stmt.where(and, query+" "+op+" ?", append(args, v)...)
+}
+
+// AndJSONContains 指定 WHERE ... AND col 包含 v
+//
+// v 会被 [json.Marshal] 转换成 JSON 文档，如果 v 本身已经是 JSON，可以使用 [json.RawMessage]。
+// 仅 [SQLBuilder.Where] 或是各语句自带的 WHERE 可用，由 [Where] 创建的实例将返回错误。
+func (stmt *WhereStmt) AndJSONContains(col string, v any) *WhereStmt {
+	return stmt.jsonContains(true, col, v)
+}
+
+// OrJSONContains 指定 WHERE ... OR col 包含 v
+//
+// 具体可参考 [WhereStmt.AndJSONContains]。
+func (stmt *WhereStmt) OrJSONContains(col string, v any) *WhereStmt {
+	return stmt.jsonContains(false, col, v)
+}
+
+func (stmt *WhereStmt) jsonContains(and bool, col string, v any) *WhereStmt {
+	if stmt.err != nil {
+		return stmt
+	}
+
+	hook, err := jsonHooker(stmt.engine)
+	if err != nil {
+		stmt.err = err
+		return stmt
+	}
+
+	doc, err := json.Marshal(v)
+	if err != nil {
+		stmt.err = err
+		return stmt
+	}
+
+	query, err := hook.JSONContainsSQL(col)
+	if err != nil {
+		stmt.err = err
+		return stmt
+	}
+	return stmt.where(and, query, string(doc))
+}
+
+func (stmt *WhereStmtOf[T]) AndJSON(col, op string, v any, path ...string) T {
+	stmt.w.AndJSON(col, op, v, path...)
+	return stmt.t
+}
+
+func (stmt *WhereStmtOf[T]) OrJSON(col, op string, v any, path ...string) T {
+	stmt.w.OrJSON(col, op, v, path...)
+	return stmt.t
+}
+
+func (stmt *WhereStmtOf[T]) AndJSONContains(col string, v any) T {
+	stmt.w.AndJSONContains(col, v)
+	return stmt.t
+}
+
+func (stmt *WhereStmtOf[T]) OrJSONContains(col string, v any) T {
+	stmt.w.OrJSONContains(col, v)
+	return stmt.t
+}
+
+// JSONColumn 将 JSON 列 col 中 path 所指定的值作为列
+//
+// alias 为列的别名，不能为空；
+// path 为 JSON 中各层级的键名，数组下标以数值表示，提取的值为文本类型。
+func (stmt *SelectStmt) JSONColumn(col, alias string, path ...string) *SelectStmt {
+	if stmt.err != nil {
+		return stmt
+	}
+
+	if alias == "" {
+		stmt.err = errors.New("参数 alias 不能为空")
+		return stmt
+	}
+
+	query, args, err := jsonExtract(stmt.Engine(), col, path)
+	if err != nil {
+		stmt.err = err
+		return stmt
+	}
+
+	stmt.columnArgs = append(stmt.columnArgs, args...)
+	return stmt.Column(query + " AS {" + alias + "}")
+}
@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package sqlbuilder
+
+import "github.com/issue9/orm/v6/core"
+
+// UpdateDeleteLimitHooker UPDATE 和 DELETE 语句中 ORDER BY 和 LIMIT 的钩子函数
+//
+// 未实现此接口的数据库，需要在 Limit 中指定用于改写语句的列。
+type UpdateDeleteLimitHooker interface {
+	// UpdateDeleteLimitHook 返回对 ORDER BY 和 LIMIT 的支持情况
+	//
+	// native 表示是否原生支持；
+	// rowid 表示不支持时用于改写语句的行标识列，为空表示不存在此类列。
+	UpdateDeleteLimitHook() (native bool, rowid string)
+}
+
+// UPDATE 和 DELETE 语句的 ORDER BY 和 LIMIT 部分
+//
+// 原生不支持的数据库会被改写为以下形式：
+//
+//	WHERE key IN (SELECT key FROM table WHERE ... ORDER BY ... LIMIT n)
+type orderLimit struct {
+	orders *core.Builder
+	limit  any // 为 nil 表示未指定
+	key    string
+}
+
+func (o *orderLimit) orderBy(asc bool, col ...string) {
+	if o.orders == nil {
+		o.orders = core.NewBuilder("")
+	}
+
+	for _, c := range col {
+		o.orders.QuoteColumn(c)
+		if asc {
+			o.orders.WString(" ASC,")
+		} else {
+			o.orders.WString(" DESC,")
+		}
+	}
+}
+
+func (o *orderLimit) setLimit(limit any, key []string) error {
+	switch len(key) {
+	case 0:
+		o.key = ""
+	case 1:
+		o.key = key[0]
+	default:
+		return SyntaxError("LIMIT", "过多的 key 参数")
+	}
+
+	o.limit = limit
+	return nil
+}
+
+func (o *orderLimit) reset() {
+	if o.orders != nil {
+		o.orders.Reset()
+	}
+	o.limit = nil
+	o.key = ""
+}
+
+func (o *orderLimit) empty() bool {
+	return o.limit == nil && (o.orders == nil || o.orders.Len() == 0)
+}
+
+// 返回是否原生支持，以及在不支持时用于改写语句的列名。
+func (o *orderLimit) native(d core.Dialect) (bool, string) {
+	native, rowid := false, ""
+	if hook, ok := d.(UpdateDeleteLimitHooker); ok {
+		native, rowid = hook.UpdateDeleteLimitHook()
+	}
+
+	if o.key != "" {
+		rowid = o.key
+	}
+	return native, rowid
+}
+
+// 以原生的语法写入 ORDER BY 和 LIMIT
+func (o *orderLimit) writeNative(b *core.Builder, d core.Dialect) []any {
+	hasOrder := o.orders != nil && o.orders.Len() > 0
+	if hasOrder {
+		b.WString(" ORDER BY ").Append(o.orders).TruncateLast(1)
+	}
+
+	if o.limit == nil {
+		return nil
+	}
+
+	if !hasOrder {
+		writeLimitOrder(b, d)
+	}
+
+	query, args := d.LimitSQL(o.limit)
+	b.WString(query)
+	return args
+}
+
+// 将 where 改写为 key IN (SELECT key FROM table WHERE where ORDER BY ... LIMIT n)
+func (o *orderLimit) rewrite(d core.Dialect, key, table, where string, args []any) (string, []any, error) {
+	if key == "" {
+		return "", nil, SyntaxError("LIMIT", "当前数据库不支持 LIMIT，需要指定用于改写语句的列")
+	}
+
+	b := core.NewBuilder("").
+		QuoteKey(key).
+		WString(" IN (SELECT ").
+		QuoteKey(key).
+		WString(" FROM ").
+		QuoteTable(table)
+	if where != "" {
+		b.WString(" WHERE ").WString(where)
+	}
+	args = append(args, o.writeNative(b, d)...)
+
+	query, err := b.WBytes(')').String()
+	if err != nil {
+		return "", nil, err
+	}
+	return query, args, nil
+}
+
+// 生成 where 部分以及其后的 ORDER BY 和 LIMIT
+//
+// joins 表示是否存在多表关联，多表操作不支持 ORDER BY 和 LIMIT；
+// 返回值中的 w 为改写之后的条件，suffix 需要写在 w 之后。
+//
+// 调用者需要保证 o.empty() 为 false。
+func (o *orderLimit) build(d core.Dialect, joins bool, table, where string, args []any) (w string, wa []any, suffix string, err error) {
+	if joins {
+		return "", nil, "", SyntaxError("LIMIT", "多表操作不支持 ORDER BY 和 LIMIT")
+	}
+
+	native, key := o.native(d)
+	if native {
+		b := core.NewBuilder("")
+		args = append(args, o.writeNative(b, d)...)
+		suffix, err = b.String()
+		return where, args, suffix, err
+	}
+
+	if o.limit == nil { // 改写之后的 ORDER BY 对结果没有影响，与其静默忽略，不如返回错误。
+		return "", nil, "", SyntaxError("ORDER BY", "当前数据库的 ORDER BY 需要与 LIMIT 一起使用")
+	}
+
+	w, wa, err = o.rewrite(d, key, table, where, args)
+	return w, wa, "", err
+}
+
+// Asc 正序
+//
+// 除了 mysql 和 mariadb，其它数据库需要与 [UpdateStmt.Limit] 一起使用，否则返回错误。
+//
+// col 为列名，格式可以是单纯的列名，或是带表名的列：
+//
+//	col
+//	table.col
+//
+// table 和 col 都可以是关键字，系统会自动处理。
+func (stmt *UpdateStmt) Asc(col ...string) *UpdateStmt {
+	stmt.orderLimit.orderBy(true, col...)
+	return stmt
+}
+
+// Desc 倒序
+//
+// 参数可参考 [UpdateStmt.Asc]。
+func (stmt *UpdateStmt) Desc(col ...string) *UpdateStmt {
+	stmt.orderLimit.orderBy(false, col...)
+	return stmt
+}
+
+// Limit 限制更新的数量
+//
+// limit 为数量，可以是 sql.NamedArg 类型；
+// key 为改写语句时所使用的唯一列，一般为主键。
+//
+// mysql 和 mariadb 会采用原生的 UPDATE ... ORDER BY ... LIMIT 语法，key 会被忽略；
+// 其它数据库则会改写为 WHERE key IN (SELECT key ... ORDER BY ... LIMIT n) 的形式，
+// 如果未指定 key，postgres 会采用 ctid，sqlite3 会采用 rowid。
+func (stmt *UpdateStmt) Limit(limit any, key ...string) *UpdateStmt {
+	if stmt.err == nil {
+		stmt.err = stmt.orderLimit.setLimit(limit, key)
+	}
+	return stmt
+}
+
+// Asc 正序
+//
+// 参数可参考 [UpdateStmt.Asc]。
+func (stmt *DeleteStmt) Asc(col ...string) *DeleteStmt {
+	stmt.orderLimit.orderBy(true, col...)
+	return stmt
+}
+
+// Desc 倒序
+//
+// 参数可参考 [UpdateStmt.Asc]。
+func (stmt *DeleteStmt) Desc(col ...string) *DeleteStmt {
+	stmt.orderLimit.orderBy(false, col...)
+	return stmt
+}
+
+// Limit 限制删除的数量
+//
+// 参数可参考 [UpdateStmt.Limit]。
+func (stmt *DeleteStmt) Limit(limit any, key ...string) *DeleteStmt {
+	if stmt.err == nil {
+		stmt.err = stmt.orderLimit.setLimit(limit, key)
+	}
+	return stmt
+}
+
+func writeLimitOrder(b *core.Builder, d core.Dialect) {
+	if hook, ok := d.(LimitOrderHooker); ok {
+		b.WString(hook.LimitOrderHook())
+	}
+}
@@ -7,6 +7,7 @@ package sqlbuilder
 import (
 	"bufio"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	"unicode"
@@ -16,8 +17,18 @@ import (
 
 var quoteReplacer = strings.NewReplacer("{", "", "}", "")
 
+// CombineSQL 将 args 替换掉 query 中的占位符，形成一条完整的语句
+//
+// query 中的占位符可以是 ? 或是 @name 形式，
+// 参数由 [core.Dialect.Literal] 转换成字面量。
+func CombineSQL(d core.Dialect, query string, args []any) (string, error) {
+	return fillArgs(d, query, args)
+}
+
 // 将参数替换成实际的值
-func fillArgs(query string, args []any) (string, error) {
+//
+// 参数由 [core.Dialect.Literal] 转换成字面量。
+func fillArgs(d core.Dialect, query string, args []any) (string, error) {
 	// 获取所有命名参数列表
 	named := make(map[string]any, len(args))
 	for _, arg := range args {
@@ -32,7 +43,11 @@ func fillArgs(query string, args []any) (string, error) {
 		if !found {
 			return fmt.Errorf("不存在该名称的参数:%s", name)
 		}
-		builder.Quote(fmt.Sprint(v), '\'', '\'')
+		l, err := d.Literal(v)
+		if err != nil {
+			return err
+		}
+		builder.WString(l)
 		return nil
 	}
 
@@ -52,7 +67,14 @@ func fillArgs(query string, args []any) (string, error) {
 			index++
 		case start == -1:
 			if c == '?' {
-				builder.Quote(fmt.Sprint(args[index]), '\'', '\'')
+				if index >= len(args) {
+					return "", errors.New("参数数量与占位符数量不相等")
+				}
+				l, err := d.Literal(args[index])
+				if err != nil {
+					return "", err
+				}
+				builder.WString(l)
 				index++
 			} else {
 				builder.WRunes(c)
@@ -66,6 +88,10 @@ func fillArgs(query string, args []any) (string, error) {
 		}
 	}
 
+	if len(named) == 0 && index != len(args) {
+		return "", errors.New("参数数量与占位符数量不相等")
+	}
+
 	return builder.String()
 }
 
@@ -7,15 +7,33 @@ package sqlbuilder
 import (
 	"bufio"
 	"database/sql"
+	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/issue9/assert/v4"
 
+	"github.com/issue9/orm/v6/core"
 	"github.com/issue9/orm/v6/internal/sqltest"
 )
 
 var _ bufio.SplitFunc = splitWithAS
 
+// 仅实现了 [core.Dialect.Literal] 的 [core.Dialect]
+type literalDialect struct {
+	core.Dialect
+}
+
+func (literalDialect) Literal(v any) (string, error) {
+	switch vv := v.(type) {
+	case int:
+		return strconv.Itoa(vv), nil
+	case string:
+		return "'" + vv + "'", nil
+	}
+	return "", fmt.Errorf("无法将 %T 转换成 SQL 字面量", v)
+}
+
 func TestFillArgs(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -33,22 +51,22 @@ func TestFillArgs(t *testing.T) {
 		{
 			query:  "select * from tbl where id=?",
 			args:   []any{1},
-			output: "select * from tbl where id='1'",
+			output: "select * from tbl where id=1",
 		},
 		{
 			query:  "select * from tbl where id=? and name=?",
 			args:   []any{1, "n"},
-			output: "select * from tbl where id='1' and name='n'",
+			output: "select * from tbl where id=1 and name='n'",
 		},
 		{
 			query:  "select * from tbl where id=? and name=@name",
 			args:   []any{1, sql.Named("name", "n")},
-			output: "select * from tbl where id='1' and name='n'",
+			output: "select * from tbl where id=1 and name='n'",
 		},
 		{
 			query:  "select * from tbl where id=? and name=@name and age>?",
 			args:   []any{1, sql.Named("name", "n"), 18},
-			output: "select * from tbl where id='1' and name='n' and age>'18'",
+			output: "select * from tbl where id=1 and name='n' and age>18",
 		},
 		{ // 类型不匹配
 			query: "select * from tbl where id=? and name=@name",
@@ -60,6 +78,21 @@ func TestFillArgs(t *testing.T) {
 			args:  []any{1, "n", sql.Named("age", 18)},
 			err:   true,
 		},
+		{ // 参数数量不足
+			query: "select * from tbl where id=? and age>?",
+			args:  []any{1},
+			err:   true,
+		},
+		{ // 参数数量过多
+			query: "select * from tbl where id=?",
+			args:  []any{1, 2},
+			err:   true,
+		},
+		{ // 无法转换的类型
+			query: "select * from tbl where id=?",
+			args:  []any{struct{}{}},
+			err:   true,
+		},
 		{ // 名称不存在
 			query: "select * from tbl where id=? and name=@name",
 			args:  []any{1, sql.Named("not-exists", "n")},
@@ -68,7 +101,7 @@ func TestFillArgs(t *testing.T) {
 	}
 
 	for index, item := range data {
-		output, err := fillArgs(item.query, item.args)
+		output, err := fillArgs(literalDialect{}, item.query, item.args)
 		if item.err {
 			a.Error(err, "%s@%d", err, index).
 				Empty(output)
@@ -17,6 +17,12 @@ import (
 // 如果没有符合条件的数据，则返回此错误。
 var ErrNoData = errors.New("不存在符合和条件的数据")
 
+// LimitOrderHooker 分页语句必须与 ORDER BY 一起使用的数据库需要实现此接口
+type LimitOrderHooker interface {
+	// LimitOrderHook 未指定 ORDER BY 时，在分页语句之前添加的排序语句
+	LimitOrderHook() string
+}
+
 // SelectQuery 预编译之后的查询语句
 type SelectQuery struct {
 	stmt *core.Stmt
@@ -29,10 +35,13 @@ type SelectStmt struct {
 	*queryStmt
 	*selectWhere
 
-	tableExpr string
-	columns   []string
-	distinct  bool
-	forUpdate bool
+	with       withClause
+	tableExpr  string
+	tableArgs  []any // FROM 子查询中的参数
+	columns    []string
+	columnArgs []any // 列表达式中的参数
+	distinct   bool
+	forUpdate  bool
 
 	// COUNT 查询的列内容
 	countExpr string
@@ -40,13 +49,16 @@ type SelectStmt struct {
 
 	unions []*unionSelect
 
-	joins  *core.Builder
-	orders *core.Builder
-	group  string
+	joins     *core.Builder
+	joinsArgs []any
+	orders    *core.Builder
+	group     string
 
 	havingQuery string
 	havingVals  []any
 
+	windows *core.Builder
+
 	limitQuery string
 	limitVals  []any
 }
@@ -79,9 +91,12 @@ func (stmt *SelectStmt) Distinct() *SelectStmt {
 func (stmt *SelectStmt) Reset() *SelectStmt {
 	stmt.baseStmt.Reset()
 
+	stmt.with.reset()
 	stmt.tableExpr = ""
+	stmt.tableArgs = nil
 	stmt.WhereStmt().Reset()
 	stmt.columns = stmt.columns[:0]
+	stmt.columnArgs = nil
 	stmt.distinct = false
 	stmt.forUpdate = false
 
@@ -95,6 +110,7 @@ func (stmt *SelectStmt) Reset() *SelectStmt {
 	if stmt.joins != nil {
 		stmt.joins.Reset()
 	}
+	stmt.joinsArgs = nil
 	if stmt.orders != nil {
 		stmt.orders.Reset()
 	}
@@ -103,6 +119,10 @@ func (stmt *SelectStmt) Reset() *SelectStmt {
 	stmt.havingQuery = ""
 	stmt.havingVals = nil
 
+	if stmt.windows != nil {
+		stmt.windows.Reset()
+	}
+
 	stmt.limitQuery = ""
 	stmt.limitVals = nil
 
@@ -119,16 +139,26 @@ func (stmt *SelectStmt) SQL() (string, []any, error) {
 		return "", nil, SyntaxError("SELECT", "未指定表名")
 	}
 
-	builder := core.NewBuilder("SELECT ")
+	builder := core.NewBuilder("")
 	args := make([]any, 0, 10)
 
+	// with
+	wa, err := stmt.with.build(builder)
+	if err != nil {
+		return "", nil, err
+	}
+	args = append(args, wa...)
+
+	builder.WString("SELECT ")
 	args = append(args, stmt.buildColumns(builder)...)
 
 	builder.WString(" FROM ").WString(stmt.tableExpr)
+	args = append(args, stmt.tableArgs...)
 
 	// join
 	if stmt.joins != nil {
 		builder.Append(stmt.joins).WBytes(' ')
+		args = append(args, stmt.joinsArgs...)
 	}
 
 	// where
@@ -153,6 +183,11 @@ func (stmt *SelectStmt) SQL() (string, []any, error) {
 		args = append(args, stmt.havingVals...)
 	}
 
+	// window
+	if stmt.windows != nil && stmt.windows.Len() > 0 {
+		builder.Append(stmt.windows)
+	}
+
 	if stmt.countExpr == "" {
 		// order by
 		if stmt.orders != nil && stmt.orders.Len() > 0 {
@@ -161,6 +196,9 @@ func (stmt *SelectStmt) SQL() (string, []any, error) {
 
 		// limit
 		if stmt.limitQuery != "" {
+			if stmt.orders == nil || stmt.orders.Len() == 0 {
+				writeLimitOrder(builder, stmt.Dialect())
+			}
 			builder.WString(stmt.limitQuery)
 			args = append(args, stmt.limitVals...)
 		}
@@ -226,7 +264,7 @@ func (stmt *SelectStmt) buildColumns(builder *core.Builder) []any {
 		builder.WString(col).WBytes(',')
 	}
 	builder.TruncateLast(1)
-	return nil
+	return stmt.columnArgs
 }
 
 // Column 指定列
@@ -247,6 +285,25 @@ func (stmt *SelectStmt) Column(col string) *SelectStmt {
 	return stmt
 }
 
+// ColumnExpr 以表达式作为列
+//
+// e 一般为 expr 包中生成的表达式，其内容在调用时即生成，
+// 表达式中的参数会排在 FROM 之前。
+func (stmt *SelectStmt) ColumnExpr(e SQLer) *SelectStmt {
+	if stmt.err != nil {
+		return stmt
+	}
+
+	query, args, err := e.SQL()
+	if err != nil {
+		stmt.err = err
+		return stmt
+	}
+
+	stmt.columnArgs = append(stmt.columnArgs, args...)
+	return stmt.Column(query)
+}
+
 // Columns 指定列名
 //
 // 相当于按参数顺序依次调用 [Select.Column]，如果存在别名，
@@ -273,7 +330,7 @@ func (stmt *SelectStmt) From(table string, alias ...string) *SelectStmt {
 		return stmt
 	}
 
-	builder := core.NewBuilder("").QuoteKey(table)
+	builder := core.NewBuilder("").QuoteTable(table)
 
 	switch len(alias) {
 	case 0:
@@ -292,6 +349,50 @@ func (stmt *SelectStmt) From(table string, alias ...string) *SelectStmt {
 	return stmt
 }
 
+// FromQuery 以子查询作为表
+//
+// sub 为子查询语句，其内容在调用时即生成，之后对 sub 的修改不会再影响当前语句；
+// alias 为子查询的别名，不能为空。
+func (stmt *SelectStmt) FromQuery(sub *SelectStmt, alias string) *SelectStmt {
+	if stmt.err != nil {
+		return stmt
+	}
+
+	if stmt.tableExpr != "" {
+		stmt.err = errors.New("不能重复指定表名")
+		return stmt
+	}
+
+	stmt.tableExpr, stmt.tableArgs, stmt.err = buildSubqueryTable(sub, alias)
+	return stmt
+}
+
+// 生成 (SELECT ...) AS alias 形式的表达式
+func buildSubqueryTable(sub *SelectStmt, alias string) (string, []any, error) {
+	if sub == nil {
+		return "", nil, SyntaxError("SELECT", "子查询不能为空")
+	}
+
+	if alias == "" {
+		return "", nil, SyntaxError("SELECT", "子查询必须指定别名")
+	}
+
+	query, args, err := sub.SQL()
+	if err != nil {
+		return "", nil, err
+	}
+
+	expr, err := core.NewBuilder("(").
+		WString(query).
+		WString(") AS ").
+		QuoteKey(alias).
+		String()
+	if err != nil {
+		return "", nil, err
+	}
+	return expr, args, nil
+}
+
 // Having 指定 having 语句
 func (stmt *SelectStmt) Having(expr string, args ...any) *SelectStmt {
 	stmt.havingQuery = expr
@@ -308,7 +409,7 @@ func (stmt *SelectStmt) Join(typ, table, alias, on string) *SelectStmt {
 	stmt.joins.WBytes(' ').
 		WString(typ).
 		WString(" JOIN ").
-		QuoteKey(table).
+		QuoteTable(table).
 		WString(" AS ").
 		QuoteKey(alias).
 		WString(" ON ").
@@ -317,6 +418,35 @@ func (stmt *SelectStmt) Join(typ, table, alias, on string) *SelectStmt {
 	return stmt
 }
 
+// JoinQuery 添加一条以子查询为表的 Join 语句
+//
+// sub 的处理方式与 [SelectStmt.FromQuery] 相同，其它参数与 [SelectStmt.Join] 相同。
+func (stmt *SelectStmt) JoinQuery(typ string, sub *SelectStmt, alias, on string) *SelectStmt {
+	if stmt.err != nil {
+		return stmt
+	}
+
+	expr, args, err := buildSubqueryTable(sub, alias)
+	if err != nil {
+		stmt.err = err
+		return stmt
+	}
+
+	if stmt.joins == nil {
+		stmt.joins = core.NewBuilder("")
+	}
+
+	stmt.joins.WBytes(' ').
+		WString(typ).
+		WString(" JOIN ").
+		WString(expr).
+		WString(" ON ").
+		WString(on)
+	stmt.joinsArgs = append(stmt.joinsArgs, args...)
+
+	return stmt
+}
+
 // Desc 倒序查询
 //
 // col 为分组的列名，格式可以是单纯的列名，或是带表名的列：
@@ -393,6 +523,8 @@ func (stmt *SelectStmt) Group(col string) *SelectStmt {
 }
 
 // Limit 生成 SQL 的 Limit 语句
+//
+// 对于实现了 [LimitOrderHooker] 的数据库，未指定 ORDER BY 时会自动添加排序语句。
 func (stmt *SelectStmt) Limit(limit any, offset ...any) *SelectStmt {
 	query, vals := stmt.Dialect().LimitSQL(limit, offset...)
 	stmt.limitQuery = query
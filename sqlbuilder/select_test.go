@@ -11,8 +11,10 @@ import (
 	"github.com/issue9/assert/v4"
 
 	"github.com/issue9/orm/v6/fetch"
+	"github.com/issue9/orm/v6/internal/sqltest"
 	"github.com/issue9/orm/v6/internal/test"
 	"github.com/issue9/orm/v6/sqlbuilder"
+	"github.com/issue9/orm/v6/sqlbuilder/expr"
 )
 
 var _ sqlbuilder.SQLer = &sqlbuilder.SelectStmt{}
@@ -295,3 +297,261 @@ func TestSelectStmt_UnionAll(t *testing.T) {
 		t.False(found)
 	})
 }
+
+func TestSelectStmt_With(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		u := sqlbuilder.Select(t.DB).Column("*").From("users").Where("id>?", 2)
+		sel := sqlbuilder.Select(t.DB).
+			With("u", u).
+			Column("id").
+			From("u").
+			Where("id<?", 5).
+			Asc("id")
+		query, args, err := sel.SQL()
+		t.NotError(err).Equal(args, []any{2, 5})
+		sqltest.Equal(a, query, "WITH {u} AS (SELECT * FROM {users} WHERE id>?) SELECT id FROM {u} WHERE id<? ORDER BY id ASC")
+
+		query, err = sel.CombineSQL()
+		t.NotError(err)
+		sqltest.Equal(a, query, "WITH {u} AS (SELECT * FROM {users} WHERE id>2) SELECT id FROM {u} WHERE id<5 ORDER BY id ASC")
+
+		rows, err := sel.Query()
+		t.NotError(err).NotNil(rows)
+		ids, err := fetch.Column[int64](false, "id", rows)
+		t.NotError(err).Equal(ids, []int64{3, 4})
+		t.NotError(rows.Close())
+
+		// 同名
+		sel.Reset()
+		sel.With("u", u).With("u", u).Column("id").From("u")
+		_, _, err = sel.SQL()
+		t.ErrorString(err, "存在同名的 WITH 表达式")
+	})
+}
+
+func TestSelectStmt_WithRecursive(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		anchor := sqlbuilder.Select(t.DB).Column("id").From("users").Where("id=?", 1)
+		recursive := sqlbuilder.Select(t.DB).
+			Column("u.id").
+			From("users", "u").
+			Join("INNER", "ids", "i", "{u}.{id}={i}.{id}+1").
+			Where("{u}.{id}<?", 4)
+		anchor.Union(true, recursive)
+
+		sel := sqlbuilder.Select(t.DB).
+			WithRecursive("ids", anchor, "id").
+			Column("id").
+			From("ids").
+			Asc("id")
+		query, args, err := sel.SQL()
+		t.NotError(err).Equal(args, []any{1, 4})
+		sqltest.Equal(a, query, "WITH RECURSIVE {ids}({id}) AS ("+
+			"SELECT id FROM {users} WHERE id=? "+
+			"UNION ALL SELECT u.id FROM {users} AS {u} INNER JOIN {ids} AS {i} ON {u}.{id}={i}.{id}+1 WHERE {u}.{id}<?"+
+			") SELECT id FROM {ids} ORDER BY id ASC")
+
+		rows, err := sel.Query()
+		t.NotError(err).NotNil(rows)
+		ids, err := fetch.Column[int64](false, "id", rows)
+		t.NotError(err).Equal(ids, []int64{1, 2, 3})
+		t.NotError(rows.Close())
+	})
+}
+
+func TestSelectStmt_Subquery(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		// 位置参数
+		sub := sqlbuilder.Select(t.DB).Column("id").From("users").Where("age>?", 3)
+		sel := sqlbuilder.Select(t.DB).
+			Column("id").
+			From("users").
+			Where("id<?", 6).
+			AndInQuery("id", sub).
+			Asc("id")
+		query, args, err := sel.SQL()
+		t.NotError(err).Equal(args, []any{6, 3})
+		sqltest.Equal(a, query, "SELECT id FROM {users} WHERE id<? AND {id} IN(SELECT id FROM {users} WHERE age>?) ORDER BY id ASC")
+
+		rows, err := sel.Query()
+		t.NotError(err).NotNil(rows)
+		ids, err := fetch.Column[int64](false, "id", rows)
+		t.NotError(err).Equal(ids, []int64{4, 5})
+		t.NotError(rows.Close())
+
+		// 命名参数
+		maxAge := sqlbuilder.Select(t.DB).Column("MAX(age)").From("users").Where("id<@max", sql.Named("max", 5))
+		exists := sqlbuilder.Select(t.DB).Column("1").From("users", "u").Where("{u}.{age}>@age", sql.Named("age", 5))
+		sel.Reset()
+		sel.Column("id").
+			From("users").
+			Where("id>@id", sql.Named("id", 1)).
+			AndCompare("age", "=", maxAge).
+			AndExists(exists)
+		rows, err = sel.Query()
+		t.NotError(err).NotNil(rows)
+		ids, err = fetch.Column[int64](false, "id", rows)
+		t.NotError(err).Equal(ids, []int64{4})
+		t.NotError(rows.Close())
+
+		// NOT EXISTS
+		sel.Reset()
+		sel.Column("id").From("users").AndNotExists(exists)
+		rows, err = sel.Query()
+		t.NotError(err).NotNil(rows)
+		ids, err = fetch.Column[int64](false, "id", rows)
+		t.NotError(err).Empty(ids)
+		t.NotError(rows.Close())
+	})
+}
+
+func TestSelectStmt_FromQuery(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		ages := sqlbuilder.Select(t.DB).
+			Column("age").
+			Column("COUNT(*) AS cnt").
+			From("users").
+			Where("id<?", 7).
+			Group("age")
+
+		sel := sqlbuilder.Select(t.DB).
+			Column("age").
+			FromQuery(ages, "t").
+			Where("cnt>?", 1)
+		query, args, err := sel.SQL()
+		t.NotError(err).Equal(args, []any{7, 1})
+		sqltest.Equal(a, query, "SELECT age FROM (SELECT age,COUNT(*) AS cnt FROM {users} WHERE id<? GROUP BY age) AS {t} WHERE cnt>?")
+
+		rows, err := sel.Query()
+		t.NotError(err).NotNil(rows)
+		list, err := fetch.Column[int64](false, "age", rows)
+		t.NotError(err).Equal(list, []int64{6})
+		t.NotError(rows.Close())
+
+		// union
+		sel.Union(false, sqlbuilder.Select(t.DB).Column("age").From("users").Where("id=?", 1))
+		query, args, err = sel.SQL()
+		t.NotError(err).Equal(args, []any{7, 1, 1})
+		sqltest.Equal(a, query, "SELECT age FROM (SELECT age,COUNT(*) AS cnt FROM {users} WHERE id<? GROUP BY age) AS {t} WHERE cnt>? "+
+			"UNION SELECT age FROM {users} WHERE id=?")
+		rows, err = sel.Query()
+		t.NotError(err).NotNil(rows)
+		list, err = fetch.Column[int64](false, "age", rows)
+		t.NotError(err).Length(list, 2)
+		t.NotError(rows.Close())
+
+		// count
+		sel.Reset()
+		sel.FromQuery(ages, "t").Where("cnt>=?", 1).Count("COUNT(*) AS c")
+		cnt, err := sel.QueryInt("c")
+		t.NotError(err).Equal(cnt, 5)
+
+		// 错误
+		sel.Reset()
+		sel.FromQuery(ages, "")
+		_, _, err = sel.SQL()
+		t.ErrorString(err, "子查询必须指定别名")
+
+		sel.Reset()
+		sel.From("users").FromQuery(ages, "t")
+		_, _, err = sel.SQL()
+		t.ErrorString(err, "不能重复指定表名")
+	})
+}
+
+func TestSelectStmt_JoinQuery(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		ages := sqlbuilder.Select(t.DB).
+			Column("age").
+			Column("COUNT(*) AS cnt").
+			From("users").
+			Where("id<?", 7).
+			Group("age")
+
+		sel := sqlbuilder.Select(t.DB).
+			Column("u.id").
+			From("users", "u").
+			JoinQuery("INNER", ages, "t", "{t}.{age}={u}.{age}").
+			Where("{t}.{cnt}>?", 1).
+			Asc("u.id")
+		query, args, err := sel.SQL()
+		t.NotError(err).Equal(args, []any{7, 1})
+		sqltest.Equal(a, query, "SELECT u.id FROM {users} AS {u} "+
+			"INNER JOIN (SELECT age,COUNT(*) AS cnt FROM {users} WHERE id<? GROUP BY age) AS {t} ON {t}.{age}={u}.{age} "+
+			"WHERE {t}.{cnt}>? ORDER BY u.id ASC")
+
+		rows, err := sel.Query()
+		t.NotError(err).NotNil(rows)
+		ids, err := fetch.Column[int64](false, "id", rows)
+		t.NotError(err).Equal(ids, []int64{5, 6})
+		t.NotError(rows.Close())
+	})
+}
+
+func TestSelectStmt_ColumnExpr(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		id := expr.Column[int64]("id")
+		age := expr.Column[int]("age")
+		sel := sqlbuilder.Select(t.DB).
+			ColumnExpr(id).
+			ColumnExpr(expr.Case[string]().When(age.Lt(3), "child").Else("adult").End().As("t")).
+			ColumnExpr(expr.Coalesce(age, expr.Value(0)).Add(1).As("next")).
+			From("users").
+			AndExpr(id.Between(2, 7)).
+			AndExpr(age.IsNull().Or(age.Gt(3))).
+			Asc("id")
+		query, args, err := sel.SQL()
+		t.NotError(err).Equal(args, []any{3, "child", "adult", 0, 1, int64(2), int64(7), 3})
+		sqltest.Equal(a, query, "SELECT {id},"+
+			"CASE WHEN {age}<? THEN ? ELSE ? END AS {t},"+
+			"(COALESCE({age},?)+?) AS {next} "+
+			"FROM {users} WHERE {id} BETWEEN ? AND ? AND ({age} IS NULL OR {age}>?) ORDER BY id ASC")
+
+		rows, err := sel.Query()
+		t.NotError(err).NotNil(rows)
+		list, err := fetch.Column[int64](false, "next", rows)
+		t.NotError(err).Equal(list, []int64{5, 7, 7, 1})
+		t.NotError(rows.Close())
+
+		// count 不包含列中的参数
+		sel.Count("COUNT(*) AS cnt")
+		cnt, err := sel.QueryInt("cnt")
+		t.NotError(err).Equal(cnt, 4)
+	})
+}
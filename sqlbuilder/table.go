@@ -18,6 +18,14 @@ type CreateTableStmt struct {
 	name  string // 索引等需要用到此值
 }
 
+// CreateTableIfNotExistsHooker 不支持 CREATE TABLE IF NOT EXISTS 语法的钩子函数
+type CreateTableIfNotExistsHooker interface {
+	// CreateTableIfNotExistsHook 将 query 改写为仅在表 table 不存在时才执行的语句
+	//
+	// query 为不包含 IF NOT EXISTS 的 CREATE TABLE 语句。
+	CreateTableIfNotExistsHook(table, query string) (string, error)
+}
+
 // CreateTable 生成创建表的语句
 func (sql *SQLBuilder) CreateTable() *CreateTableStmt { return CreateTable(sql.engine) }
 
@@ -210,15 +218,20 @@ func (stmt *CreateTableStmt) DDLSQL() ([]string, error) {
 		return nil, SyntaxError("CREATE TABLE", "未指定列")
 	}
 
-	w := core.NewBuilder("CREATE TABLE IF NOT EXISTS ").
-		QuoteKey(stmt.model.Name).
-		WBytes('(')
+	hook, isHook := stmt.Dialect().(CreateTableIfNotExistsHooker)
+	w := core.NewBuilder("CREATE TABLE ")
+	if !isHook {
+		w.WString("IF NOT EXISTS ")
+	}
+	w.QuoteTable(stmt.model.Name).WBytes('(')
 
+	var sqls []string
 	for _, col := range stmt.model.Columns {
-		typ, err := stmt.Dialect().SQLType(col)
+		typ, ddl, err := columnType(stmt.Dialect(), stmt.name, col)
 		if err != nil {
 			return nil, err
 		}
+		sqls = append(sqls, ddl...)
 		w.QuoteKey(col.Name).WBytes(' ').WString(typ).WBytes(',')
 	}
 
@@ -235,7 +248,12 @@ func (stmt *CreateTableStmt) DDLSQL() ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
-	sqls := []string{q}
+	if isHook {
+		if q, err = hook.CreateTableIfNotExistsHook(stmt.model.Name, q); err != nil {
+			return nil, err
+		}
+	}
+	sqls = append(sqls, q)
 
 	indexes, err := createIndexSQL(stmt)
 	if err != nil {
@@ -255,6 +273,20 @@ func (stmt *CreateTableStmt) createConstraints(buf *core.Builder) error {
 		buf.WBytes(',')
 	}
 
+	// 未采用原生类型的枚举列
+	for _, col := range stmt.model.Columns {
+		if len(col.Enums) == 0 || IsNativeEnum(stmt.Dialect(), col) {
+			continue
+		}
+
+		expr, err := EnumCheckExpr(stmt.Dialect(), col)
+		if err != nil {
+			return err
+		}
+		stmt.createCheckSQL(buf, EnumCheckName(stmt.name, col.Name), expr)
+		buf.WBytes(',')
+	}
+
 	for _, u := range stmt.model.Uniques {
 		stmt.createUniqueSQL(buf, u)
 		buf.WBytes(',')
@@ -337,7 +369,7 @@ func (stmt *CreateTableStmt) createFKSQL(buf *core.Builder, fk *core.ForeignKey)
 		QuoteKey(fk.Column.Name)
 
 	buf.WString(") REFERENCES ").
-		QuoteKey(fk.RefTableName)
+		QuoteTable(fk.RefTableName)
 
 	buf.WBytes('(').
 		QuoteKey(fk.RefColName).
@@ -407,6 +439,14 @@ func (stmt *TruncateTableStmt) DDLSQL() ([]string, error) {
 	return stmt.Dialect().TruncateTableSQL(stmt.tableName, stmt.aiColumnName)
 }
 
+// DropTableIfExistsHooker 不支持 DROP TABLE IF EXISTS 语法的钩子函数
+type DropTableIfExistsHooker interface {
+	// DropTableIfExistsHook 将 query 改写为仅在表 table 存在时才执行的语句
+	//
+	// query 为不包含 IF EXISTS 的 DROP TABLE 语句。
+	DropTableIfExistsHook(table, query string) (string, error)
+}
+
 // DropTableStmt 删除表语句
 type DropTableStmt struct {
 	*ddlStmt
@@ -449,16 +489,25 @@ func (stmt *DropTableStmt) DDLSQL() ([]string, error) {
 		return nil, SyntaxError("DROP TABLE", "未指定表名")
 	}
 
+	hook, isHook := stmt.Dialect().(DropTableIfExistsHooker)
 	qs := make([]string, 0, len(stmt.tables))
 
 	for _, table := range stmt.tables {
-		q, err := core.NewBuilder("DROP TABLE IF EXISTS ").
-			QuoteKey(table).
-			String()
+		b := core.NewBuilder("DROP TABLE ")
+		if !isHook {
+			b.WString("IF EXISTS ")
+		}
+		q, err := b.QuoteTable(table).String()
 		if err != nil {
 			return nil, err
 		}
 
+		if isHook {
+			if q, err = hook.DropTableIfExistsHook(table, q); err != nil {
+				return nil, err
+			}
+		}
+
 		qs = append(qs, q)
 	}
 	return qs, nil
@@ -469,6 +518,67 @@ func (stmt *DropTableStmt) Reset() *DropTableStmt {
 	return stmt
 }
 
+// RenameTableStmtHooker RenameTableStmt.DDLSQL 的钩子函数
+type RenameTableStmtHooker interface {
+	RenameTableStmtHook(*RenameTableStmt) ([]string, error)
+}
+
+// RenameTableStmt 重命名表
+type RenameTableStmt struct {
+	*ddlStmt
+	OldName, Name string
+}
+
+// RenameTable 生成重命名表的语句
+func (sql *SQLBuilder) RenameTable() *RenameTableStmt { return RenameTable(sql.engine) }
+
+// RenameTable 声明一条重命名表的语句
+func RenameTable(e core.Engine) *RenameTableStmt {
+	stmt := &RenameTableStmt{}
+	stmt.ddlStmt = newDDLStmt(e, stmt)
+	return stmt
+}
+
+// Table 将表 old 重命名为 name
+//
+// NOTE: 重复指定，会覆盖之前的。
+func (stmt *RenameTableStmt) Table(old, name string) *RenameTableStmt {
+	stmt.OldName = old
+	stmt.Name = name
+	return stmt
+}
+
+func (stmt *RenameTableStmt) DDLSQL() ([]string, error) {
+	if stmt.err != nil {
+		return nil, stmt.Err()
+	}
+
+	if stmt.OldName == "" || stmt.Name == "" {
+		return nil, SyntaxError("RENAME TABLE", "未指定表名")
+	}
+
+	if hook, ok := stmt.Dialect().(RenameTableStmtHooker); ok {
+		return hook.RenameTableStmtHook(stmt)
+	}
+
+	q, err := core.NewBuilder("ALTER TABLE ").
+		QuoteTable(stmt.OldName).
+		WString(" RENAME TO ").
+		QuoteKey(stmt.Name).
+		String()
+	if err != nil {
+		return nil, err
+	}
+	return []string{q}, nil
+}
+
+func (stmt *RenameTableStmt) Reset() *RenameTableStmt {
+	stmt.baseStmt.Reset()
+	stmt.OldName = ""
+	stmt.Name = ""
+	return stmt
+}
+
 type TableExistsStmt struct {
 	*queryStmt
 	name string
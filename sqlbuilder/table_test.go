@@ -131,6 +131,32 @@ func TestTruncateTable(t *testing.T) {
 	})
 }
 
+func TestRenameTable(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		err := sqlbuilder.CreateTable(t.DB).
+			Table("users").
+			AutoIncrement("id", core.Int64).
+			Exec()
+		a.NotError(err)
+		defer func() {
+			a.NotError(sqlbuilder.DropTable(t.DB).Table("users", "members").Exec())
+		}()
+
+		stmt := sqlbuilder.RenameTable(t.DB)
+		t.NotError(stmt.Table("users", "members").Exec())
+
+		exists, err := sqlbuilder.TableExists(t.DB).Table("members").Exists()
+		t.NotError(err).True(exists)
+		exists, err = sqlbuilder.TableExists(t.DB).Table("users").Exists()
+		t.NotError(err).False(exists)
+
+		a.ErrorString(stmt.Reset().Exec(), "未指定表名")
+	})
+}
+
 func TestDropTable(t *testing.T) {
 	a := assert.New(t, false)
 	suite := test.NewSuite(a, "")
@@ -17,8 +17,11 @@ type UpdateStmt struct {
 	*execStmt
 	*updateWhere
 
-	table  string
-	values []*updateSet
+	with       withClause
+	table      string
+	joins      tableJoins
+	values     []*updateSet
+	orderLimit orderLimit
 
 	occColumn string // 乐观锁的列名
 	occValue  any    // 乐观锁的当前值
@@ -30,7 +33,7 @@ type updateWhere = WhereStmtOf[*UpdateStmt]
 type updateSet struct {
 	column string
 	value  any
-	typ    byte // 类型，可以是 + 自增类型，- 自减类型，或是空值表示正常表达式
+	typ    byte // 类型，可以是 + 自增类型，- 自减类型，= 表达式类型，或是空值表示正常值
 }
 
 // Update 生成更新语句
@@ -63,6 +66,20 @@ func (stmt *UpdateStmt) Set(col string, val any) *UpdateStmt {
 	return stmt
 }
 
+// SetExpr 以表达式设置列的值，若 col 相同，则会覆盖
+//
+// e 一般为 expr 包中生成的表达式，比如：
+//
+//	stmt.SetExpr("age", expr.Column[int]("age").Add(1))
+func (stmt *UpdateStmt) SetExpr(col string, e SQLer) *UpdateStmt {
+	stmt.values = append(stmt.values, &updateSet{
+		column: col,
+		value:  e,
+		typ:    '=',
+	})
+	return stmt
+}
+
 // Increase 给列增加值
 func (stmt *UpdateStmt) Increase(col string, val any) *UpdateStmt {
 	stmt.values = append(stmt.values, &updateSet{
@@ -97,7 +114,10 @@ func (stmt *UpdateStmt) OCC(col string, val any) *UpdateStmt {
 func (stmt *UpdateStmt) Reset() *UpdateStmt {
 	stmt.baseStmt.Reset()
 
+	stmt.with.reset()
 	stmt.table = ""
+	stmt.joins.reset()
+	stmt.orderLimit.reset()
 	stmt.WhereStmt().Reset()
 	stmt.values = stmt.values[:0]
 
@@ -117,17 +137,44 @@ func (stmt *UpdateStmt) SQL() (string, []any, error) {
 		return "", nil, err
 	}
 
-	buf := core.NewBuilder("UPDATE ").
-		QuoteKey(stmt.table).
-		WString(" SET ")
+	if err := stmt.with.check(stmt.Engine()); err != nil {
+		return "", nil, err
+	}
+
+	buf := core.NewBuilder("")
+	args, err := stmt.with.build(buf)
+	if err != nil {
+		return "", nil, err
+	}
+
+	syntax := JoinSubquery
+	if len(stmt.joins) > 0 {
+		syntax = joinSyntax(stmt.Engine(), true)
+	}
 
-	args := make([]any, 0, len(stmt.values))
+	buf.WString("UPDATE ").QuoteTable(stmt.table)
+	if len(stmt.joins) > 0 && syntax == JoinMysql {
+		stmt.joins.writeJoins(buf)
+	}
+	buf.WString(" SET ")
 
 	for _, val := range stmt.values {
-		buf.QuoteKey(val.column).WBytes('=')
+		stmt.writeColumn(buf, syntax, val.column)
+		buf.WBytes('=')
+
+		if val.typ == '=' {
+			q, a, err := val.value.(SQLer).SQL()
+			if err != nil {
+				return "", nil, err
+			}
+			buf.WString(q).WBytes(',')
+			args = append(args, a...)
+			continue
+		}
 
 		if val.typ != 0 {
-			buf.QuoteKey(val.column).WBytes(val.typ)
+			stmt.writeColumn(buf, syntax, val.column)
+			buf.WBytes(val.typ)
 		}
 
 		if named, ok := val.value.(sql.NamedArg); ok && named.Name != "" {
@@ -145,11 +192,32 @@ func (stmt *UpdateStmt) SQL() (string, []any, error) {
 		return "", nil, err
 	}
 
-	if wq != "" {
-		buf.WString(" WHERE ").WString(wq)
-		args = append(args, wa...)
+	var suffix string
+	if !stmt.orderLimit.empty() {
+		wq, wa, suffix, err = stmt.orderLimit.build(stmt.Dialect(), len(stmt.joins) > 0, stmt.table, wq, wa)
+		if err != nil {
+			return "", nil, err
+		}
 	}
 
+	args = append(args, wa...)
+
+	switch {
+	case len(stmt.joins) == 0 || syntax == JoinMysql:
+		if wq != "" {
+			buf.WString(" WHERE ").WString(wq)
+		}
+	case syntax == JoinFrom:
+		buf.WString(" FROM ")
+		stmt.joins.writeTables(buf)
+		buf.WString(" WHERE ")
+		stmt.joins.writeConds(buf, wq)
+	default:
+		buf.WString(" WHERE ")
+		stmt.joins.writeExists(buf, wq)
+	}
+	buf.WString(suffix)
+
 	query, err := buf.String()
 	if err != nil {
 		return "", nil, err
@@ -157,6 +225,14 @@ func (stmt *UpdateStmt) SQL() (string, []any, error) {
 	return query, args, nil
 }
 
+// mysql 的多表更新需要在列名之前加上表名，以免与关联表中的列名冲突。
+func (stmt *UpdateStmt) writeColumn(buf *core.Builder, syntax JoinSyntax, col string) {
+	if len(stmt.joins) > 0 && syntax == JoinMysql {
+		buf.QuoteTable(stmt.table).WBytes('.')
+	}
+	buf.QuoteKey(col)
+}
+
 func (stmt *UpdateStmt) getWhereSQL() (string, []any, error) {
 	if stmt.occColumn == "" {
 		return stmt.WhereStmt().SQL()
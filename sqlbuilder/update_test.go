@@ -9,8 +9,10 @@ import (
 
 	"github.com/issue9/assert/v4"
 
+	"github.com/issue9/orm/v6/internal/sqltest"
 	"github.com/issue9/orm/v6/internal/test"
 	"github.com/issue9/orm/v6/sqlbuilder"
+	"github.com/issue9/orm/v6/sqlbuilder/expr"
 )
 
 var _ sqlbuilder.ExecStmt = &sqlbuilder.UpdateStmt{}
@@ -156,3 +158,139 @@ func TestUpdateStmt_OCC(t *testing.T) {
 		t.Equal(val, 100)
 	})
 }
+
+func TestUpdateStmt_With(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		old := sqlbuilder.Select(t.DB).Column("id").From("users").Where("id<?", 3)
+		u := sqlbuilder.Update(t.DB).
+			With("old", old).
+			Table("users").
+			Set("age", 100).
+			Where("id IN (SELECT id FROM {old})")
+		query, args, err := u.SQL()
+		t.NotError(err).Equal(args, []any{3, 100})
+		sqltest.Equal(a, query, "WITH {old} AS (SELECT id FROM {users} WHERE id<?) UPDATE {users} SET {age}=? WHERE id IN (SELECT id FROM {old})")
+
+		r, err := u.Exec()
+		t.NotError(err)
+		cnt, err := r.RowsAffected()
+		t.NotError(err).Equal(cnt, 2)
+	})
+}
+
+func TestUpdateStmt_Join(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		_, err := sqlbuilder.Insert(t.DB).Table("info").
+			Columns("uid", "tel", "nickname", "address").
+			Values(1, "1", "n1", "a1").
+			Values(2, "2", "n2", "a2").
+			Exec()
+		t.NotError(err)
+
+		u := sqlbuilder.Update(t.DB).
+			Table("users").
+			Join("info", "i", "{i}.{uid}={users}.{id}").
+			Set("age", 100).
+			Where("{i}.{tel}=?", "1")
+		query, args, err := u.SQL()
+		t.NotError(err).Equal(args, []any{100, "1"})
+		switch t.Name {
+		case "mysql", "mariadb":
+			sqltest.Equal(a, query, "UPDATE {users} INNER JOIN {info} AS {i} ON {i}.{uid}={users}.{id} SET {users}.{age}=? WHERE {i}.{tel}=?")
+		default:
+			sqltest.Equal(a, query, "UPDATE {users} SET {age}=? FROM {info} AS {i} WHERE ({i}.{uid}={users}.{id}) AND ({i}.{tel}=?)")
+		}
+
+		r, err := u.Exec()
+		t.NotError(err)
+		cnt, err := r.RowsAffected()
+		t.NotError(err).Equal(cnt, 1)
+
+		age, err := sqlbuilder.Select(t.DB).Column("age").From("users").Where("id=?", 1).QueryInt("age")
+		t.NotError(err).Equal(age, 100)
+
+		u.Reset()
+		u.Table("users").Join("", "i", "{i}.{uid}={users}.{id}").Set("age", 1)
+		_, _, err = u.SQL()
+		t.ErrorString(err, "未指定表名")
+	})
+}
+
+func TestUpdateStmt_Limit(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		u := sqlbuilder.Update(t.DB).
+			Table("users").
+			Set("age", 100).
+			Where("id>?", 1).
+			Desc("id").
+			Limit(2, "id")
+		query, args, err := u.SQL()
+		t.NotError(err).Equal(args, []any{100, 1, 2})
+		switch t.Name {
+		case "mysql", "mariadb":
+			sqltest.Equal(a, query, "UPDATE {users} SET {age}=? WHERE id>? ORDER BY {id} DESC LIMIT ?")
+		default:
+			sqltest.Equal(a, query, "UPDATE {users} SET {age}=? WHERE {id} IN (SELECT {id} FROM {users} WHERE id>? ORDER BY {id} DESC LIMIT ?)")
+		}
+
+		r, err := u.Exec()
+		t.NotError(err)
+		cnt, err := r.RowsAffected()
+		t.NotError(err).Equal(cnt, 2)
+
+		cnt, err = sqlbuilder.Select(t.DB).Count("COUNT(*) AS cnt").From("users").Where("age=?", 100).AndIn("id", 6, 7).QueryInt("cnt")
+		t.NotError(err).Equal(cnt, 2)
+	})
+}
+
+func TestUpdateStmt_SetExpr(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		age := expr.Column[int]("age")
+		u := sqlbuilder.Update(t.DB).
+			Table("users").
+			SetExpr("age", age.Mul(2).Add(1)).
+			Set("name", "n").
+			AndExpr(expr.Column[int64]("id").In(1, 2))
+		query, args, err := u.SQL()
+		t.NotError(err).Equal(args, []any{2, 1, "n", int64(1), int64(2)})
+		sqltest.Equal(a, query, "UPDATE {users} SET {age}=(({age}*?)+?),{name}=? WHERE {id} IN(?,?)")
+
+		r, err := u.Exec()
+		t.NotError(err)
+		cnt, err := r.RowsAffected()
+		t.NotError(err).Equal(cnt, 2)
+
+		v, err := sqlbuilder.Select(t.DB).Column("age").From("users").Where("id=?", 2).QueryInt("age")
+		t.NotError(err).Equal(v, 5)
+
+		// 重复的列
+		u.Reset()
+		u.Table("users").SetExpr("age", age.Add(1)).Set("age", 1)
+		_, _, err = u.SQL()
+		t.ErrorString(err, "存在重复的列名")
+	})
+}
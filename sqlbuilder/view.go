@@ -138,7 +138,7 @@ func (stmt *DropViewStmt) DDLSQL() ([]string, error) {
 	}
 
 	query, err := core.NewBuilder("DROP VIEW IF EXISTS ").
-		QuoteKey(stmt.name).
+		QuoteTable(stmt.name).
 		String()
 	if err != nil {
 		return nil, err
@@ -4,7 +4,12 @@
 
 package sqlbuilder
 
-import "github.com/issue9/orm/v6/core"
+import (
+	"slices"
+	"unicode/utf8"
+
+	"github.com/issue9/orm/v6/core"
+)
 
 // WhereStmt SQL 语句的 where 部分
 type WhereStmt struct {
@@ -13,6 +18,9 @@ type WhereStmt struct {
 
 	builder *core.Builder
 	args    []any
+	err     error
+
+	engine core.Engine // 用于生成与数据库相关的表达式，可以为空。
 }
 
 // WhereStmtOf 用于将 [WhereStmt] 的方法与其它对象组合
@@ -22,13 +30,18 @@ type WhereStmtOf[T any] struct {
 }
 
 // Where 生成 [Where] 语句
-func (sql *SQLBuilder) Where() *WhereStmt { return Where() }
+func (sql *SQLBuilder) Where() *WhereStmt { return newWhere(sql.engine) }
 
 // Where 生成 [Where] 语句
-func Where() *WhereStmt {
+//
+// 返回的实例未关联数据库，无法使用 [WhereStmt.AndJSON] 等与数据库相关的方法。
+func Where() *WhereStmt { return newWhere(nil) }
+
+func newWhere(e core.Engine) *WhereStmt {
 	return &WhereStmt{
 		builder: core.NewBuilder(""),
 		args:    make([]any, 0, 10),
+		engine:  e,
 	}
 }
 
@@ -39,17 +52,22 @@ func (stmt *WhereStmt) Reset() {
 
 	stmt.builder.Reset()
 	stmt.args = stmt.args[:0]
+	stmt.err = nil
 }
 
 // SQL 生成 SQL 语句和对应的参数返回
 func (stmt *WhereStmt) SQL() (string, []any, error) {
+	if stmt.err != nil {
+		return "", nil, stmt.err
+	}
+
 	cnt := 0
 	bs, err := stmt.builder.Bytes()
 	if err != nil {
 		return "", nil, err
 	}
-	for _, c := range bs {
-		if c == '?' || c == '@' {
+	for i, c := range bs {
+		if c == '?' || (c == '@' && isNamedArg(bs[i+1:])) {
 			cnt++
 		}
 	}
@@ -77,6 +95,15 @@ func (stmt *WhereStmt) SQL() (string, []any, error) {
 	return query, stmt.args, nil
 }
 
+// @ 之后是否为参数名称，用于区分命名参数与 postgres 的 @> 等操作符。
+func isNamedArg(bs []byte) bool {
+	if len(bs) == 0 {
+		return false
+	}
+	c := bs[0]
+	return c >= utf8.RuneSelf || c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
 func (stmt *WhereStmt) buildGroup(and bool, g *WhereStmt) error {
 	query, args, err := g.SQL()
 	if err != nil {
@@ -132,6 +159,29 @@ func (stmt *WhereStmt) Or(cond string, args ...any) *WhereStmt {
 	return stmt.where(false, cond, args...)
 }
 
+// AndExpr 添加一条 AND 表达式
+//
+// e 一般为 expr 包中生成的条件表达式，其内容在调用时即生成。
+func (stmt *WhereStmt) AndExpr(e SQLer) *WhereStmt { return stmt.whereExpr(true, e) }
+
+// OrExpr 添加一条 OR 表达式
+//
+// e 一般为 expr 包中生成的条件表达式，其内容在调用时即生成。
+func (stmt *WhereStmt) OrExpr(e SQLer) *WhereStmt { return stmt.whereExpr(false, e) }
+
+func (stmt *WhereStmt) whereExpr(and bool, e SQLer) *WhereStmt {
+	if stmt.err != nil {
+		return stmt
+	}
+
+	query, args, err := e.SQL()
+	if err != nil {
+		stmt.err = err
+		return stmt
+	}
+	return stmt.where(and, query, args...)
+}
+
 // AndIsNull 指定 WHERE ... AND col IS NULL
 func (stmt *WhereStmt) AndIsNull(col string) *WhereStmt {
 	stmt.writeAnd(true)
@@ -267,9 +317,114 @@ func (stmt *WhereStmt) in(and, not bool, col string, v ...any) *WhereStmt {
 	return stmt
 }
 
+// AndInQuery 指定 WHERE ... AND col IN(SELECT ...)
+//
+// sub 的内容在调用时即生成，之后对 sub 的修改不会再影响当前语句。
+// sub 中的参数会合并到当前语句中，可以是位置参数或是命名参数。
+func (stmt *WhereStmt) AndInQuery(col string, sub *SelectStmt) *WhereStmt {
+	return stmt.inQuery(true, false, col, sub)
+}
+
+// OrInQuery 指定 WHERE ... OR col IN(SELECT ...)
+func (stmt *WhereStmt) OrInQuery(col string, sub *SelectStmt) *WhereStmt {
+	return stmt.inQuery(false, false, col, sub)
+}
+
+// AndNotInQuery 指定 WHERE ... AND col NOT IN(SELECT ...)
+func (stmt *WhereStmt) AndNotInQuery(col string, sub *SelectStmt) *WhereStmt {
+	return stmt.inQuery(true, true, col, sub)
+}
+
+// OrNotInQuery 指定 WHERE ... OR col NOT IN(SELECT ...)
+func (stmt *WhereStmt) OrNotInQuery(col string, sub *SelectStmt) *WhereStmt {
+	return stmt.inQuery(false, true, col, sub)
+}
+
+func (stmt *WhereStmt) inQuery(and, not bool, col string, sub *SelectStmt) *WhereStmt {
+	op := "IN"
+	if not {
+		op = "NOT IN"
+	}
+	return stmt.subquery(and, col, op, sub)
+}
+
+// AndExists 指定 WHERE ... AND EXISTS(SELECT ...)
+//
+// sub 的处理方式与 [WhereStmt.AndInQuery] 相同。
+func (stmt *WhereStmt) AndExists(sub *SelectStmt) *WhereStmt {
+	return stmt.subquery(true, "", "EXISTS", sub)
+}
+
+// OrExists 指定 WHERE ... OR EXISTS(SELECT ...)
+func (stmt *WhereStmt) OrExists(sub *SelectStmt) *WhereStmt {
+	return stmt.subquery(false, "", "EXISTS", sub)
+}
+
+// AndNotExists 指定 WHERE ... AND NOT EXISTS(SELECT ...)
+func (stmt *WhereStmt) AndNotExists(sub *SelectStmt) *WhereStmt {
+	return stmt.subquery(true, "", "NOT EXISTS", sub)
+}
+
+// OrNotExists 指定 WHERE ... OR NOT EXISTS(SELECT ...)
+func (stmt *WhereStmt) OrNotExists(sub *SelectStmt) *WhereStmt {
+	return stmt.subquery(false, "", "NOT EXISTS", sub)
+}
+
+var compareOperators = []string{"=", "<>", "!=", "<", "<=", ">", ">="}
+
+// AndCompare 指定 WHERE ... AND col op (SELECT ...)
+//
+// op 为比较运算符，可以是 =、<>、!=、<、<=、> 和 >=；
+// sub 应该只返回单行单列的数据，其处理方式与 [WhereStmt.AndInQuery] 相同。
+func (stmt *WhereStmt) AndCompare(col, op string, sub *SelectStmt) *WhereStmt {
+	return stmt.compare(true, col, op, sub)
+}
+
+// OrCompare 指定 WHERE ... OR col op (SELECT ...)
+func (stmt *WhereStmt) OrCompare(col, op string, sub *SelectStmt) *WhereStmt {
+	return stmt.compare(false, col, op, sub)
+}
+
+func (stmt *WhereStmt) compare(and bool, col, op string, sub *SelectStmt) *WhereStmt {
+	if !slices.Contains(compareOperators, op) {
+		if stmt.err == nil {
+			stmt.err = SyntaxError("WHERE", "无效的比较运算符："+op)
+		}
+		return stmt
+	}
+	return stmt.subquery(and, col, op, sub)
+}
+
+// col 为空表示没有左操作数，比如 EXISTS。
+func (stmt *WhereStmt) subquery(and bool, col, op string, sub *SelectStmt) *WhereStmt {
+	if stmt.err != nil {
+		return stmt
+	}
+
+	if sub == nil {
+		stmt.err = SyntaxError("WHERE", "子查询不能为空")
+		return stmt
+	}
+
+	query, args, err := sub.SQL()
+	if err != nil {
+		stmt.err = err
+		return stmt
+	}
+
+	stmt.writeAnd(and)
+	if col != "" {
+		stmt.builder.QuoteColumn(col).WBytes(' ')
+	}
+	stmt.builder.WString(op).WBytes('(').WString(query).WBytes(')')
+	stmt.args = append(stmt.args, args...)
+
+	return stmt
+}
+
 // AndGroup 开始一个子条件语句
 func (stmt *WhereStmt) AndGroup(f func(*WhereStmt)) *WhereStmt {
-	w := Where()
+	w := newWhere(stmt.engine)
 	f(w)
 	stmt.appendGroup(true, w)
 	return stmt
@@ -277,7 +432,7 @@ func (stmt *WhereStmt) AndGroup(f func(*WhereStmt)) *WhereStmt {
 
 // OrGroup 开始一个子条件语句
 func (stmt *WhereStmt) OrGroup(f func(*WhereStmt)) *WhereStmt {
-	w := Where()
+	w := newWhere(stmt.engine)
 	f(w)
 	stmt.appendGroup(false, w)
 	return stmt
@@ -322,8 +477,15 @@ func (stmt *WhereStmt) Cond(expr bool, f func(stmt *WhereStmt)) *WhereStmt {
 	return stmt
 }
 
+// NewWhereStmtOf 声明 [WhereStmtOf]
+//
+// 如果 t 包含了 Engine() [core.Engine] 方法，则生成的 [WhereStmt] 会关联该数据库。
 func NewWhereStmtOf[T any](t T) *WhereStmtOf[T] {
-	return &WhereStmtOf[T]{w: Where(), t: t}
+	var e core.Engine
+	if engine, ok := any(t).(interface{ Engine() core.Engine }); ok {
+		e = engine.Engine()
+	}
+	return &WhereStmtOf[T]{w: newWhere(e), t: t}
 }
 
 func (stmt *WhereStmtOf[T]) Where(cond string, args ...any) T {
@@ -341,6 +503,18 @@ func (stmt *WhereStmtOf[T]) Or(cond string, args ...any) T {
 	return stmt.t
 }
 
+// AndExpr 添加一条 AND 表达式
+func (stmt *WhereStmtOf[T]) AndExpr(e SQLer) T {
+	stmt.w.AndExpr(e)
+	return stmt.t
+}
+
+// OrExpr 添加一条 OR 表达式
+func (stmt *WhereStmtOf[T]) OrExpr(e SQLer) T {
+	stmt.w.OrExpr(e)
+	return stmt.t
+}
+
 // AndIsNull 指定 WHERE ... AND col IS NULL
 func (stmt *WhereStmtOf[T]) AndIsNull(col string) T {
 	stmt.w.AndIsNull(col)
@@ -437,6 +611,66 @@ func (stmt *WhereStmtOf[T]) OrNotIn(col string, v ...any) T {
 	return stmt.t
 }
 
+// AndInQuery 指定 WHERE ... AND col IN(SELECT ...)
+func (stmt *WhereStmtOf[T]) AndInQuery(col string, sub *SelectStmt) T {
+	stmt.w.AndInQuery(col, sub)
+	return stmt.t
+}
+
+// OrInQuery 指定 WHERE ... OR col IN(SELECT ...)
+func (stmt *WhereStmtOf[T]) OrInQuery(col string, sub *SelectStmt) T {
+	stmt.w.OrInQuery(col, sub)
+	return stmt.t
+}
+
+// AndNotInQuery 指定 WHERE ... AND col NOT IN(SELECT ...)
+func (stmt *WhereStmtOf[T]) AndNotInQuery(col string, sub *SelectStmt) T {
+	stmt.w.AndNotInQuery(col, sub)
+	return stmt.t
+}
+
+// OrNotInQuery 指定 WHERE ... OR col NOT IN(SELECT ...)
+func (stmt *WhereStmtOf[T]) OrNotInQuery(col string, sub *SelectStmt) T {
+	stmt.w.OrNotInQuery(col, sub)
+	return stmt.t
+}
+
+// AndExists 指定 WHERE ... AND EXISTS(SELECT ...)
+func (stmt *WhereStmtOf[T]) AndExists(sub *SelectStmt) T {
+	stmt.w.AndExists(sub)
+	return stmt.t
+}
+
+// OrExists 指定 WHERE ... OR EXISTS(SELECT ...)
+func (stmt *WhereStmtOf[T]) OrExists(sub *SelectStmt) T {
+	stmt.w.OrExists(sub)
+	return stmt.t
+}
+
+// AndNotExists 指定 WHERE ... AND NOT EXISTS(SELECT ...)
+func (stmt *WhereStmtOf[T]) AndNotExists(sub *SelectStmt) T {
+	stmt.w.AndNotExists(sub)
+	return stmt.t
+}
+
+// OrNotExists 指定 WHERE ... OR NOT EXISTS(SELECT ...)
+func (stmt *WhereStmtOf[T]) OrNotExists(sub *SelectStmt) T {
+	stmt.w.OrNotExists(sub)
+	return stmt.t
+}
+
+// AndCompare 指定 WHERE ... AND col op (SELECT ...)
+func (stmt *WhereStmtOf[T]) AndCompare(col, op string, sub *SelectStmt) T {
+	stmt.w.AndCompare(col, op, sub)
+	return stmt.t
+}
+
+// OrCompare 指定 WHERE ... OR col op (SELECT ...)
+func (stmt *WhereStmtOf[T]) OrCompare(col, op string, sub *SelectStmt) T {
+	stmt.w.OrCompare(col, op, sub)
+	return stmt.t
+}
+
 // AndGroup 开始一个子条件语句
 func (stmt *WhereStmtOf[T]) AndGroup(f func(*WhereStmt)) T {
 	stmt.w.AndGroup(f)
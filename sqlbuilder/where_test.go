@@ -5,11 +5,13 @@
 package sqlbuilder
 
 import (
+	"database/sql"
 	"testing"
 
 	"github.com/issue9/assert/v4"
 
 	"github.com/issue9/orm/v6/internal/sqltest"
+	"github.com/issue9/orm/v6/sqlbuilder/expr"
 )
 
 var _ SQLer = &WhereStmt{}
@@ -182,6 +184,70 @@ func TestWhereStmt_In(t *testing.T) {
 	sqltest.Equal(a, query, "{col1} not in(?,?,?) and {col2} not in(?,?,?)")
 }
 
+func TestWhereStmt_InQuery(t *testing.T) {
+	a := assert.New(t, false)
+	w := Where()
+
+	sub := Select(nil).Column("uid").From("info").Where("tel=?", "1")
+	w.And("id>?", 1).AndInQuery("id", sub)
+	query, args, err := w.SQL()
+	a.NotError(err).Equal(args, []any{1, "1"})
+	sqltest.Equal(a, query, "id>? and {id} in(select uid from {info} where tel=?)")
+
+	w.Reset()
+	w.OrNotInQuery("t.id", sub).AndNotInQuery("id", Select(nil))
+	query, args, err = w.SQL()
+	a.ErrorString(err, "未指定表名").Nil(args).Empty(query)
+
+	w.Reset()
+	w.AndInQuery("id", nil)
+	_, _, err = w.SQL()
+	a.ErrorString(err, "子查询不能为空")
+}
+
+func TestWhereStmt_Exists(t *testing.T) {
+	a := assert.New(t, false)
+	w := Where()
+
+	sub := Select(nil).Column("1").From("info").Where("{info}.{uid}={users}.{id}")
+	w.AndExists(sub).OrNotExists(sub.Reset().Column("1").From("info").Where("tel=@tel", sql.Named("tel", "1")))
+	query, args, err := w.SQL()
+	a.NotError(err).Equal(args, []any{sql.Named("tel", "1")})
+	sqltest.Equal(a, query, "exists(select 1 from {info} where {info}.{uid}={users}.{id}) or not exists(select 1 from {info} where tel=@tel)")
+}
+
+func TestWhereStmt_Compare(t *testing.T) {
+	a := assert.New(t, false)
+	w := Where()
+
+	sub := Select(nil).Column("max(age)").From("users").Where("id<?", 3)
+	w.AndCompare("age", ">=", sub).OrCompare("age", "=", sub)
+	query, args, err := w.SQL()
+	a.NotError(err).Equal(args, []any{3, 3})
+	sqltest.Equal(a, query, "{age} >=(select max(age) from {users} where id<?) or {age} =(select max(age) from {users} where id<?)")
+
+	w.Reset()
+	w.AndCompare("age", "like", sub)
+	_, _, err = w.SQL()
+	a.ErrorString(err, "无效的比较运算符")
+}
+
+func TestWhereStmt_Expr(t *testing.T) {
+	a := assert.New(t, false)
+	w := Where()
+	id := expr.Column[int]("id")
+
+	w.And("age>?", 1).AndExpr(id.In(1, 2)).OrExpr(expr.Lower(expr.Column[string]("name")).Eq("n"))
+	query, args, err := w.SQL()
+	a.NotError(err).Equal(args, []any{1, 1, 2, "n"})
+	sqltest.Equal(a, query, "age>? AND {id} IN(?,?) OR LOWER({name})=?")
+
+	w.Reset()
+	w.AndExpr(expr.Case[int]().End().Eq(1))
+	_, _, err = w.SQL()
+	a.ErrorString(err, "CASE 表达式至少需要一个 WHEN 子句")
+}
+
 func TestWhereStmt_Group(t *testing.T) {
 	a := assert.New(t, false)
 	w := Where()
@@ -243,3 +309,22 @@ func TestWhereStmt_Cond(t *testing.T) {
 	a.Equal(args, []any{1})
 	sqltest.Equal(a, query, "id=?")
 }
+
+func TestWhere_JSON(t *testing.T) {
+	a := assert.New(t, false)
+
+	// 未关联数据库
+	w := Where().AndJSON("data", "=", 1, "a")
+	_, _, err := w.SQL()
+	a.Error(err)
+
+	w = Where().OrJSONContains("data", 1)
+	_, _, err = w.SQL()
+	a.Error(err)
+
+	// @> 不是命名参数
+	w = Where().And("{data} @> ?", `{"a":1}`).And("{id}=@id", sql.Named("id", 1))
+	query, args, err := w.SQL()
+	a.NotError(err).Length(args, 2)
+	sqltest.Equal(a, query, "{data} @> ? and {id}=@id")
+}
@@ -0,0 +1,389 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package sqlbuilder
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/issue9/orm/v6/core"
+)
+
+var errBasePartition = SyntaxError("PARTITION BY", "继承命名窗口时不能指定 PARTITION BY")
+
+// 窗口帧的边界
+const (
+	UnboundedPreceding FrameBound = "UNBOUNDED PRECEDING"
+	CurrentRow         FrameBound = "CURRENT ROW"
+	UnboundedFollowing FrameBound = "UNBOUNDED FOLLOWING"
+)
+
+// FrameBound 窗口帧的边界
+//
+// 除了预定义的几个常量之外，还可以通过 [Preceding] 和 [Following] 生成。
+type FrameBound string
+
+// WindowStmt 窗口定义
+//
+// 表示 OVER 或是 WINDOW 语句中括号内的内容：
+//
+//	[base] [PARTITION BY ...] [ORDER BY ...] [frame]
+//
+// 支持 sqlite3>=3.25、mysql>=8.0、mariadb>=10.2 和 postgres，
+// 其中 GROUPS 帧仅支持 sqlite3>=3.28 和 postgres>=11。
+type WindowStmt struct {
+	base       string
+	partitions *core.Builder
+	orders     *core.Builder
+	frame      string
+	err        error
+}
+
+// WindowFunc 窗口函数
+//
+// 可以通过 [SelectStmt.ColumnWindow] 添加到查询语句的列中。
+type WindowFunc struct {
+	expr   string
+	over   *WindowStmt
+	window string // 引用的命名窗口
+	err    error
+}
+
+// Preceding 表示当前行之前的第 n 行
+func Preceding(n int) FrameBound { return FrameBound(strconv.Itoa(n) + " PRECEDING") }
+
+// Following 表示当前行之后的第 n 行
+func Following(n int) FrameBound { return FrameBound(strconv.Itoa(n) + " FOLLOWING") }
+
+// Window 声明窗口定义
+func Window() *WindowStmt {
+	return &WindowStmt{
+		partitions: core.NewBuilder(""),
+		orders:     core.NewBuilder(""),
+	}
+}
+
+// Window 声明窗口定义
+func (sql *SQLBuilder) Window() *WindowStmt { return Window() }
+
+// Base 指定继承的命名窗口
+//
+// 继承的窗口由 [SelectStmt.Window] 定义。
+// 继承命名窗口时不能再指定 PARTITION BY，分区只能由被继承的窗口定义。
+func (w *WindowStmt) Base(name string) *WindowStmt {
+	if w.err != nil {
+		return w
+	}
+
+	if name != "" && w.partitions.Len() > 0 {
+		w.err = errBasePartition
+		return w
+	}
+
+	w.base = name
+	return w
+}
+
+// PartitionBy 指定分区的列
+//
+// col 格式可以是单纯的列名，或是带表名的列：
+//
+//	col
+//	table.col
+//
+// table 和 col 都可以是关键字，系统会自动处理。
+// 如果已经通过 [WindowStmt.Base] 继承了命名窗口，会返回错误。
+func (w *WindowStmt) PartitionBy(col ...string) *WindowStmt {
+	if w.err != nil {
+		return w
+	}
+
+	if w.base != "" && len(col) > 0 {
+		w.err = errBasePartition
+		return w
+	}
+
+	for _, c := range col {
+		w.partitions.QuoteColumn(c).WBytes(',')
+	}
+	return w
+}
+
+// Asc 正序
+//
+// col 的格式与 [WindowStmt.PartitionBy] 相同。
+func (w *WindowStmt) Asc(col ...string) *WindowStmt { return w.orderBy(true, col...) }
+
+// Desc 倒序
+//
+// col 的格式与 [WindowStmt.PartitionBy] 相同。
+func (w *WindowStmt) Desc(col ...string) *WindowStmt { return w.orderBy(false, col...) }
+
+func (w *WindowStmt) orderBy(asc bool, col ...string) *WindowStmt {
+	for _, c := range col {
+		w.orders.QuoteColumn(c)
+		if asc {
+			w.orders.WString(" ASC,")
+		} else {
+			w.orders.WString(" DESC,")
+		}
+	}
+	return w
+}
+
+// Rows 以行为单位的窗口帧
+//
+// end 为空表示仅指定了起始边界。
+func (w *WindowStmt) Rows(start, end FrameBound) *WindowStmt { return w.setFrame("ROWS", start, end) }
+
+// Range 以值为单位的窗口帧
+//
+// end 为空表示仅指定了起始边界。
+func (w *WindowStmt) Range(start, end FrameBound) *WindowStmt {
+	return w.setFrame("RANGE", start, end)
+}
+
+// Groups 以值相同的行为一组作为单位的窗口帧
+//
+// end 为空表示仅指定了起始边界。
+//
+// NOTE: mysql 和 mariadb 不支持此模式。
+func (w *WindowStmt) Groups(start, end FrameBound) *WindowStmt {
+	return w.setFrame("GROUPS", start, end)
+}
+
+func (w *WindowStmt) setFrame(typ string, start, end FrameBound) *WindowStmt {
+	if w.err != nil {
+		return w
+	}
+
+	if start == "" {
+		w.err = errors.New("未指定窗口帧的起始边界")
+		return w
+	}
+
+	if end == "" {
+		w.frame = typ + " " + string(start)
+	} else {
+		w.frame = typ + " BETWEEN " + string(start) + " AND " + string(end)
+	}
+	return w
+}
+
+// Reset 重置内容
+func (w *WindowStmt) Reset() *WindowStmt {
+	w.base = ""
+	w.partitions.Reset()
+	w.orders.Reset()
+	w.frame = ""
+	w.err = nil
+	return w
+}
+
+// SQL 生成窗口定义的内容
+//
+// 返回的内容包含了首尾的括号。
+func (w *WindowStmt) SQL() (string, error) {
+	if w.err != nil {
+		return "", w.err
+	}
+
+	b := core.NewBuilder("(")
+
+	if w.base != "" {
+		b.QuoteKey(w.base).WBytes(' ')
+	}
+
+	if w.partitions.Len() > 0 {
+		b.WString("PARTITION BY ").Append(w.partitions).TruncateLast(1).WBytes(' ')
+	}
+
+	if w.orders.Len() > 0 {
+		b.WString("ORDER BY ").Append(w.orders).TruncateLast(1).WBytes(' ')
+	}
+
+	if w.frame != "" {
+		b.WString(w.frame).WBytes(' ')
+	}
+
+	if b.Len() > 1 {
+		b.TruncateLast(1)
+	}
+	return b.WBytes(')').String()
+}
+
+// NewWindowFunc 声明窗口函数
+//
+// expr 为函数表达式，比如 ROW_NUMBER()、SUM({amount}) 等，
+// 其中的关键字需要自行使用 {} 包含。
+// 一般情况下可以直接使用 [RowNumber]、[Lag] 等预定义的函数。
+func NewWindowFunc(expr string) *WindowFunc { return &WindowFunc{expr: expr} }
+
+// Over 指定窗口函数的窗口定义
+//
+// 与 [WindowFunc.OverWindow] 会相互覆盖。
+func (f *WindowFunc) Over(w *WindowStmt) *WindowFunc {
+	f.over = w
+	f.window = ""
+	return f
+}
+
+// OverWindow 指定窗口函数引用的命名窗口
+//
+// 命名窗口由 [SelectStmt.Window] 定义，与 [WindowFunc.Over] 会相互覆盖。
+func (f *WindowFunc) OverWindow(name string) *WindowFunc {
+	f.window = name
+	f.over = nil
+	return f
+}
+
+// SQL 生成窗口函数的表达式
+func (f *WindowFunc) SQL() (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+
+	if f.expr == "" {
+		return "", SyntaxError("OVER", "未指定窗口函数")
+	}
+
+	b := core.NewBuilder(f.expr).WString(" OVER ")
+	switch {
+	case f.window != "":
+		b.QuoteKey(f.window)
+	case f.over != nil:
+		w, err := f.over.SQL()
+		if err != nil {
+			return "", err
+		}
+		b.WString(w)
+	default:
+		b.WString("()")
+	}
+
+	return b.String()
+}
+
+// RowNumber ROW_NUMBER() 窗口函数
+func RowNumber() *WindowFunc { return NewWindowFunc("ROW_NUMBER()") }
+
+// Rank RANK() 窗口函数
+func Rank() *WindowFunc { return NewWindowFunc("RANK()") }
+
+// DenseRank DENSE_RANK() 窗口函数
+func DenseRank() *WindowFunc { return NewWindowFunc("DENSE_RANK()") }
+
+// PercentRank PERCENT_RANK() 窗口函数
+func PercentRank() *WindowFunc { return NewWindowFunc("PERCENT_RANK()") }
+
+// CumeDist CUME_DIST() 窗口函数
+func CumeDist() *WindowFunc { return NewWindowFunc("CUME_DIST()") }
+
+// NTile NTILE(n) 窗口函数
+func NTile(n int) *WindowFunc { return NewWindowFunc("NTILE(" + strconv.Itoa(n) + ")") }
+
+// Lag LAG(col, offset) 窗口函数
+func Lag(col string, offset int) *WindowFunc { return columnWindowFunc("LAG", col, offset) }
+
+// Lead LEAD(col, offset) 窗口函数
+func Lead(col string, offset int) *WindowFunc { return columnWindowFunc("LEAD", col, offset) }
+
+// FirstValue FIRST_VALUE(col) 窗口函数
+func FirstValue(col string) *WindowFunc { return columnWindowFunc("FIRST_VALUE", col, -1) }
+
+// LastValue LAST_VALUE(col) 窗口函数
+func LastValue(col string) *WindowFunc { return columnWindowFunc("LAST_VALUE", col, -1) }
+
+// NthValue NTH_VALUE(col, n) 窗口函数
+func NthValue(col string, n int) *WindowFunc { return columnWindowFunc("NTH_VALUE", col, n) }
+
+// AggregateWindow 将聚合函数作为窗口函数
+//
+// fn 为聚合函数名，比如 SUM、AVG 和 COUNT 等；
+// col 为列名，如果是 * 则不会添加引号。
+//
+//	AggregateWindow("SUM", "amount").Over(Window().Asc("id")) // 累计值
+func AggregateWindow(fn, col string) *WindowFunc { return columnWindowFunc(fn, col, -1) }
+
+// n 小于 0 表示没有第二个参数
+func columnWindowFunc(fn, col string, n int) *WindowFunc {
+	b := core.NewBuilder(fn).WBytes('(')
+	if col == "*" {
+		b.WBytes('*')
+	} else {
+		b.QuoteColumn(col)
+	}
+
+	if n >= 0 {
+		b.WBytes(',').WString(strconv.Itoa(n))
+	}
+
+	expr, err := b.WBytes(')').String()
+	if err != nil {
+		return &WindowFunc{err: err}
+	}
+	return NewWindowFunc(expr)
+}
+
+// ColumnWindow 添加窗口函数作为列
+//
+// alias 为列的别名，可以为空。
+//
+//	stmt.ColumnWindow(RowNumber().Over(Window().PartitionBy("uid").Desc("created")), "rn")
+func (stmt *SelectStmt) ColumnWindow(f *WindowFunc, alias string) *SelectStmt {
+	if stmt.err != nil {
+		return stmt
+	}
+
+	expr, err := f.SQL()
+	if err != nil {
+		stmt.err = err
+		return stmt
+	}
+
+	if alias != "" {
+		expr, err = core.NewBuilder(expr).WString(" AS ").QuoteKey(alias).String()
+		if err != nil {
+			stmt.err = err
+			return stmt
+		}
+	}
+
+	return stmt.Column(expr)
+}
+
+// Window 定义命名窗口
+//
+// 生成 WINDOW name AS (...) 语句，之后可以在 [WindowFunc.OverWindow]
+// 或是 [WindowStmt.Base] 中引用该名称。
+func (stmt *SelectStmt) Window(name string, w *WindowStmt) *SelectStmt {
+	if stmt.err != nil {
+		return stmt
+	}
+
+	if name == "" {
+		stmt.err = SyntaxError("WINDOW", "未指定窗口名称")
+		return stmt
+	}
+
+	query, err := w.SQL()
+	if err != nil {
+		stmt.err = err
+		return stmt
+	}
+
+	if stmt.windows == nil {
+		stmt.windows = core.NewBuilder("")
+	}
+
+	if stmt.windows.Len() == 0 {
+		stmt.windows.WString(" WINDOW ")
+	} else {
+		stmt.windows.WBytes(',')
+	}
+	stmt.windows.QuoteKey(name).WString(" AS ").WString(query)
+
+	return stmt
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package sqlbuilder_test
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v4"
+
+	"github.com/issue9/orm/v6/fetch"
+	"github.com/issue9/orm/v6/internal/sqltest"
+	"github.com/issue9/orm/v6/internal/test"
+	"github.com/issue9/orm/v6/sqlbuilder"
+)
+
+func TestWindowStmt_SQL(t *testing.T) {
+	a := assert.New(t, false)
+
+	w := sqlbuilder.Window()
+	query, err := w.SQL()
+	a.NotError(err).Equal(query, "()")
+
+	w.PartitionBy("uid", "t.group").Desc("created").Asc("id")
+	query, err = w.SQL()
+	a.NotError(err)
+	sqltest.Equal(a, query, "(PARTITION BY {uid},{t}.{group} ORDER BY {created} DESC,{id} ASC)")
+
+	w.Rows(sqlbuilder.UnboundedPreceding, sqlbuilder.CurrentRow)
+	query, err = w.SQL()
+	a.NotError(err)
+	sqltest.Equal(a, query, "(PARTITION BY {uid},{t}.{group} ORDER BY {created} DESC,{id} ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)")
+
+	w.Reset().Base("w").Range(sqlbuilder.Preceding(2), "")
+	query, err = w.SQL()
+	a.NotError(err)
+	sqltest.Equal(a, query, "({w} RANGE 2 PRECEDING)")
+
+	w.Reset().Groups(sqlbuilder.Preceding(1), sqlbuilder.Following(1))
+	query, err = w.SQL()
+	a.NotError(err)
+	sqltest.Equal(a, query, "(GROUPS BETWEEN 1 PRECEDING AND 1 FOLLOWING)")
+
+	w.Reset().Rows("", sqlbuilder.CurrentRow)
+	query, err = w.SQL()
+	a.Error(err).Empty(query)
+
+	// 继承命名窗口时不能指定 PARTITION BY
+	query, err = w.Reset().Base("w").PartitionBy("uid").SQL()
+	a.Error(err).Empty(query)
+
+	query, err = w.Reset().PartitionBy("uid").Base("w").SQL()
+	a.Error(err).Empty(query)
+
+	query, err = w.Reset().Base("w").Asc("id").SQL()
+	a.NotError(err)
+	sqltest.Equal(a, query, "({w} ORDER BY {id} ASC)")
+}
+
+func TestWindowFunc_SQL(t *testing.T) {
+	a := assert.New(t, false)
+
+	query, err := sqlbuilder.RowNumber().SQL()
+	a.NotError(err).Equal(query, "ROW_NUMBER() OVER ()")
+
+	query, err = sqlbuilder.Lag("t.age", 1).Over(sqlbuilder.Window().Asc("id")).SQL()
+	a.NotError(err)
+	sqltest.Equal(a, query, "LAG({t}.{age},1) OVER (ORDER BY {id} ASC)")
+
+	query, err = sqlbuilder.AggregateWindow("COUNT", "*").OverWindow("w").SQL()
+	a.NotError(err)
+	sqltest.Equal(a, query, "COUNT(*) OVER {w}")
+
+	query, err = sqlbuilder.NewWindowFunc("").SQL()
+	a.Error(err).Empty(query)
+}
+
+func TestSelectStmt_ColumnWindow(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		sel := sqlbuilder.Select(t.DB).
+			Column("id").
+			ColumnWindow(sqlbuilder.RowNumber().Over(sqlbuilder.Window().PartitionBy("age").Desc("id")), "rn").
+			From("users").
+			Where("id<?", 7).
+			Asc("id")
+		query, args, err := sel.SQL()
+		t.NotError(err).Equal(args, []any{7})
+		sqltest.Equal(a, query, "SELECT id,ROW_NUMBER() OVER (PARTITION BY {age} ORDER BY {id} DESC) AS {rn} FROM {users} WHERE id<? ORDER BY id ASC")
+
+		rows, err := sel.Query()
+		t.NotError(err).NotNil(rows)
+		rns, err := fetch.Column[int64](false, "rn", rows)
+		t.NotError(err).Equal(rns, []int64{1, 1, 1, 1, 2, 1})
+		t.NotError(rows.Close())
+	})
+}
+
+func TestSelectStmt_Window(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		initDB(t)
+		defer clearDB(t)
+
+		sel := sqlbuilder.Select(t.DB).
+			Column("id").
+			ColumnWindow(sqlbuilder.AggregateWindow("SUM", "age").OverWindow("w"), "total").
+			From("users").
+			Where("id<?", 7).
+			Window("w", sqlbuilder.Window().Asc("id").Rows(sqlbuilder.UnboundedPreceding, sqlbuilder.CurrentRow)).
+			Asc("id")
+		query, args, err := sel.SQL()
+		t.NotError(err).Equal(args, []any{7})
+		sqltest.Equal(a, query, "SELECT id,SUM({age}) OVER {w} AS {total} FROM {users} WHERE id<? "+
+			"WINDOW {w} AS (ORDER BY {id} ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) ORDER BY id ASC")
+
+		rows, err := sel.Query()
+		t.NotError(err).NotNil(rows)
+		totals, err := fetch.Column[int64](false, "total", rows)
+		t.NotError(err).Equal(totals, []int64{1, 3, 6, 10, 16, 22})
+		t.NotError(rows.Close())
+
+		// 未指定窗口名称
+		sel.Reset()
+		sel.Column("id").From("users").Window("", sqlbuilder.Window())
+		_, _, err = sel.SQL()
+		t.Error(err)
+	})
+}
@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package sqlbuilder
+
+import (
+	"errors"
+
+	"github.com/issue9/orm/v6/core"
+)
+
+// WithHooker 判断数据库是否支持在 UPDATE 和 DELETE 语句中使用 WITH
+//
+// 未实现该接口的数据库表示支持。
+type WithHooker interface {
+	// WithHook 返回非空值表示 e 不支持在 UPDATE 和 DELETE 中使用 WITH
+	//
+	// 返回的错误会作为 [UpdateStmt.SQL] 和 [DeleteStmt.SQL] 的错误返回。
+	WithHook(e core.Engine) error
+}
+
+// 公共表表达式，即 WITH 语句部分。
+//
+// 只要其中一个表达式是递归的，整个语句都会被声明为 WITH RECURSIVE。
+type withClause struct {
+	recursive bool
+	ctes      []*cte
+}
+
+type cte struct {
+	name string
+	cols []string
+	sel  *SelectStmt
+}
+
+func (w *withClause) with(recursive bool, name string, sel *SelectStmt, cols []string) error {
+	if name == "" {
+		return errors.New("未指定 WITH 表达式的名称")
+	}
+
+	if sel == nil {
+		return errors.New("WITH 表达式的查询语句不能为空")
+	}
+
+	for _, c := range w.ctes {
+		if c.name == name {
+			return errors.New("存在同名的 WITH 表达式：" + name)
+		}
+	}
+
+	if recursive {
+		w.recursive = true
+	}
+	w.ctes = append(w.ctes, &cte{name: name, cols: cols, sel: sel})
+	return nil
+}
+
+// 检测 e 是否支持在 UPDATE 和 DELETE 中使用 WITH
+func (w *withClause) check(e core.Engine) error {
+	if len(w.ctes) == 0 {
+		return nil
+	}
+
+	if h, ok := e.Dialect().(WithHooker); ok {
+		return h.WithHook(e)
+	}
+	return nil
+}
+
+func (w *withClause) reset() {
+	w.recursive = false
+	w.ctes = w.ctes[:0]
+}
+
+// 将 WITH 语句写入 b，并返回其中的参数。
+//
+// 如果不存在任何表达式，则不会写入任何内容。
+func (w *withClause) build(b *core.Builder) ([]any, error) {
+	if len(w.ctes) == 0 {
+		return nil, nil
+	}
+
+	b.WString("WITH ")
+	if w.recursive {
+		b.WString("RECURSIVE ")
+	}
+
+	args := make([]any, 0, 10)
+	for _, c := range w.ctes {
+		query, a, err := c.sel.SQL()
+		if err != nil {
+			return nil, err
+		}
+
+		b.QuoteKey(c.name)
+		if len(c.cols) > 0 {
+			b.WBytes('(')
+			for _, col := range c.cols {
+				b.QuoteKey(col).WBytes(',')
+			}
+			b.TruncateLast(1).WBytes(')')
+		}
+		b.WString(" AS (").WString(query).WString("),")
+		args = append(args, a...)
+	}
+	b.TruncateLast(1).WBytes(' ')
+
+	return args, nil
+}
+
+// With 添加一条公共表表达式
+//
+// name 为表达式的名称，之后的语句中可以像普通表一样引用该名称；
+// sel 为表达式的内容；
+// cols 为表达式的列名，如果为空，则采用 sel 中的列名。
+//
+// 多次调用会添加多个表达式，表达式中的参数会排在主语句之前。
+//
+// NOTE: mysql<8.0 不支持 WITH 语句。[UpdateStmt] 和 [DeleteStmt] 中的 WITH 语句，
+// 会由实现了 [WithHooker] 的数据库进行检测，不支持时返回错误。
+func (stmt *SelectStmt) With(name string, sel *SelectStmt, cols ...string) *SelectStmt {
+	if stmt.err == nil {
+		stmt.err = stmt.with.with(false, name, sel, cols)
+	}
+	return stmt
+}
+
+// WithRecursive 添加一条递归的公共表表达式
+//
+// 参数可参考 [SelectStmt.With]，只要存在一条递归的表达式，
+// 整个语句都会以 WITH RECURSIVE 开头。
+func (stmt *SelectStmt) WithRecursive(name string, sel *SelectStmt, cols ...string) *SelectStmt {
+	if stmt.err == nil {
+		stmt.err = stmt.with.with(true, name, sel, cols)
+	}
+	return stmt
+}
+
+// With 添加一条公共表表达式
+//
+// 参数可参考 [SelectStmt.With]。
+func (stmt *UpdateStmt) With(name string, sel *SelectStmt, cols ...string) *UpdateStmt {
+	if stmt.err == nil {
+		stmt.err = stmt.with.with(false, name, sel, cols)
+	}
+	return stmt
+}
+
+// WithRecursive 添加一条递归的公共表表达式
+//
+// 参数可参考 [SelectStmt.WithRecursive]。
+func (stmt *UpdateStmt) WithRecursive(name string, sel *SelectStmt, cols ...string) *UpdateStmt {
+	if stmt.err == nil {
+		stmt.err = stmt.with.with(true, name, sel, cols)
+	}
+	return stmt
+}
+
+// With 添加一条公共表表达式
+//
+// 参数可参考 [SelectStmt.With]。
+func (stmt *DeleteStmt) With(name string, sel *SelectStmt, cols ...string) *DeleteStmt {
+	if stmt.err == nil {
+		stmt.err = stmt.with.with(false, name, sel, cols)
+	}
+	return stmt
+}
+
+// WithRecursive 添加一条递归的公共表表达式
+//
+// 参数可参考 [SelectStmt.WithRecursive]。
+func (stmt *DeleteStmt) WithRecursive(name string, sel *SelectStmt, cols ...string) *DeleteStmt {
+	if stmt.err == nil {
+		stmt.err = stmt.with.with(true, name, sel, cols)
+	}
+	return stmt
+}
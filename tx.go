@@ -30,6 +30,10 @@ func (db *DB) Begin() (*Tx, error) { return db.BeginTx(context.Background(), nil
 
 // BeginTx 开始一个新的事务
 func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	if db.script != nil {
+		return nil, errors.New("DryRun 模式不支持事务")
+	}
+
 	tx, err := db.DB().BeginTx(ctx, opts)
 	if err != nil {
 		return nil, err
@@ -99,6 +103,14 @@ func (tx *Tx) DeleteContext(ctx context.Context, v TableNamer) (sql.Result, erro
 	return del(ctx, tx, v)
 }
 
+func (tx *Tx) DeleteMany(v ...TableNamer) (int64, error) {
+	return tx.DeleteManyContext(context.Background(), v...)
+}
+
+func (tx *Tx) DeleteManyContext(ctx context.Context, v ...TableNamer) (int64, error) {
+	return delMany(ctx, tx, v...)
+}
+
 func (tx *Tx) Create(v ...TableNamer) error { return tx.CreateContext(context.Background(), v...) }
 
 func (tx *Tx) CreateContext(ctx context.Context, v ...TableNamer) error {
@@ -183,6 +195,14 @@ func (e *txEngine) DeleteContext(ctx context.Context, v TableNamer) (sql.Result,
 	return del(ctx, e, v)
 }
 
+func (e *txEngine) DeleteMany(v ...TableNamer) (int64, error) {
+	return e.DeleteManyContext(context.Background(), v...)
+}
+
+func (e *txEngine) DeleteManyContext(ctx context.Context, v ...TableNamer) (int64, error) {
+	return delMany(ctx, e, v...)
+}
+
 func (e *txEngine) Update(v TableNamer, cols ...string) (sql.Result, error) {
 	return e.UpdateContext(context.Background(), v, cols...)
 }
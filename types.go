@@ -84,6 +84,15 @@ type (
 		DeleteContext(ctx context.Context, v TableNamer) (sql.Result, error)
 		Delete(v TableNamer) (sql.Result, error)
 
+		// DeleteManyContext 删除多条相同类型的数据
+		//
+		// 查找条件与 [Engine.DeleteContext] 相同，但会将相同条件的对象合并为
+		// DELETE ... WHERE key IN (...) 语句分批执行。
+		//
+		// affected 表示所有语句影响的行数之和。
+		DeleteManyContext(ctx context.Context, v ...TableNamer) (affected int64, err error)
+		DeleteMany(v ...TableNamer) (affected int64, err error)
+
 		// UpdateContext 更新数据
 		//
 		// 零值不会被提交，cols 指定的列，即使是零值也会被更新。
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/issue9/orm/v6/core"
+)
+
+// JSONType [JSON] 对应的 [core.PrimitiveType]
+//
+// 在 postgres 和 cockroach 中为 JSONB，mysql 和 mariadb 中为 JSON，
+// 其它数据库则与 [core.String] 相同，比如 sqlite3 中的 TEXT。
+var JSONType = core.RegisterPrimitiveType(&core.CustomType{
+	Name: "json",
+	SQLTypes: map[string]string{
+		"postgres":  "JSONB",
+		"cockroach": "JSONB",
+		"mysql":     "JSON",
+		"mariadb":   "JSON",
+	},
+	Fallback: core.String,
+})
+
+// JSON 以 JSON 格式保存的数据
+//
+// 与 [SliceOf] 不同，在支持的数据库中会采用原生的 JSON 类型保存，
+// 可以通过 sqlbuilder.WhereStmt.AndJSON 等方法查询其中的内容。
+type JSON[T any] struct {
+	Data T
+}
+
+func (n *JSON[T]) Scan(value any) error {
+	var j []byte
+	switch v := value.(type) {
+	case nil:
+		var zero T
+		n.Data = zero
+		return nil
+	case string:
+		j = []byte(v)
+	case []byte:
+		j = v
+	default:
+		return core.ErrInvalidColumnType()
+	}
+
+	return json.Unmarshal(j, &n.Data)
+}
+
+// Value 返回 JSON 字符串
+//
+// 部分驱动会将 []byte 作为二进制数据处理，所以此处返回字符串。
+func (n JSON[T]) Value() (driver.Value, error) {
+	data, err := json.Marshal(n.Data)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (n JSON[T]) PrimitiveType() core.PrimitiveType { return JSONType }
+
+func (n JSON[T]) MarshalJSON() ([]byte, error) { return json.Marshal(n.Data) }
+
+func (n *JSON[T]) UnmarshalJSON(data []byte) error { return json.Unmarshal(data, &n.Data) }
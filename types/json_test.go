@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package types
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+
+	"github.com/issue9/orm/v6/core"
+)
+
+type jsonObject struct {
+	Name string `json:"name"`
+	Tags []int  `json:"tags"`
+}
+
+var (
+	_ sql.Scanner         = &JSON[jsonObject]{}
+	_ driver.Valuer       = JSON[jsonObject]{}
+	_ core.PrimitiveTyper = &JSON[jsonObject]{}
+)
+
+func TestJSON(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(core.GetPrimitiveType(reflect.TypeFor[JSON[jsonObject]]()), JSONType).
+		Equal(JSONType.String(), "json")
+
+	j := &JSON[jsonObject]{}
+	a.NotError(j.Scan(`{"name":"n","tags":[1,2]}`)).
+		Equal(j.Data, jsonObject{Name: "n", Tags: []int{1, 2}})
+
+	a.NotError(j.Scan(nil)).Zero(j.Data)
+
+	a.NotError(j.Scan([]byte(`{"name":"n"}`))).
+		Equal(j.Data, jsonObject{Name: "n"})
+
+	a.Error(j.Scan(1))
+	a.Error(j.Scan("{"))
+
+	v, err := JSON[jsonObject]{Data: jsonObject{Name: "n"}}.Value()
+	a.NotError(err).Equal(v, `{"name":"n","tags":null}`)
+
+	// MarshalJSON
+
+	data, err := json.Marshal(&JSON[[]int]{Data: []int{1, 2}})
+	a.NotError(err).Equal(string(data), "[1,2]")
+
+	m := &JSON[map[string]int]{}
+	a.NotError(json.Unmarshal([]byte(`{"a":1}`), m)).
+		Equal(m.Data, map[string]int{"a": 1})
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package types
+
+import (
+	"database/sql/driver"
+
+	"github.com/google/uuid"
+
+	"github.com/issue9/orm/v6/core"
+)
+
+// UUIDType [UUID] 对应的 [core.PrimitiveType]
+//
+// 在 postgres 和 cockroach 中为 UUID，oracle 中为 RAW(16)，其它数据库则为 BINARY(16) 或是 BLOB。
+var UUIDType = core.RegisterPrimitiveType(&core.CustomType{
+	Name: "uuid",
+	SQLTypes: map[string]string{
+		"postgres":  "UUID",
+		"cockroach": "UUID",
+		"mysql":     "BINARY(16)",
+		"mariadb":   "BINARY(16)",
+		"mssql":     "BINARY(16)",
+		"oracle":    "RAW(16)",
+		"sqlite3":   "BLOB",
+	},
+	Fallback: core.Bytes,
+})
+
+// TextUUIDType [TextUUID] 对应的 [core.PrimitiveType]
+//
+// 在 postgres 和 cockroach 中为 UUID，sqlite3 中为 TEXT，其它数据库则为 CHAR(36)。
+var TextUUIDType = core.RegisterPrimitiveType(&core.CustomType{
+	Name: "text_uuid",
+	SQLTypes: map[string]string{
+		"postgres":  "UUID",
+		"cockroach": "UUID",
+		"mysql":     "CHAR(36)",
+		"mariadb":   "CHAR(36)",
+		"mssql":     "CHAR(36)",
+		"oracle":    "CHAR(36)",
+		"sqlite3":   "TEXT",
+	},
+	Fallback: core.String,
+})
+
+// UUID 以二进制形式保存的 UUID
+//
+// 在原生支持 UUID 的数据库中采用原生类型，其它数据库则保存为 16 字节的二进制数据。
+// 零值表示未设置，可以配合 uuid(v7) 标签在插入数据时自动生成。
+type UUID [16]byte
+
+// TextUUID 以文本形式保存的 UUID
+//
+// 与 [UUID] 相同，但是在不支持 UUID 的数据库中以 36 个字符的文本形式保存，
+// 比如 sqlite3 中需要直接查看数据的情况。
+type TextUUID UUID
+
+// NewUUIDv4 生成随机的 UUID
+func NewUUIDv4() (UUID, error) {
+	u, err := uuid.NewRandom()
+	return UUID(u), err
+}
+
+// NewUUIDv7 生成以时间排序的 UUID
+//
+// 相较于 v4，v7 作为主键时插入的位置总是在末尾，对索引更友好。
+func NewUUIDv7() (UUID, error) {
+	u, err := uuid.NewV7()
+	return UUID(u), err
+}
+
+// ParseUUID 从字符串中解析 UUID
+//
+// 支持 xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx 以及不带 - 等格式。
+func ParseUUID(s string) (UUID, error) {
+	u, err := uuid.Parse(s)
+	return UUID(u), err
+}
+
+// String 返回 xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx 格式的字符串
+func (u UUID) String() string { return uuid.UUID(u).String() }
+
+// IsZero 是否为零值
+func (u UUID) IsZero() bool { return u == UUID{} }
+
+func (u *UUID) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*u = UUID{}
+		return nil
+	case string:
+		return u.parse([]byte(v))
+	case []byte:
+		if len(v) == 16 {
+			copy(u[:], v)
+			return nil
+		}
+		return u.parse(v)
+	default:
+		return core.ErrInvalidColumnType()
+	}
+}
+
+func (u *UUID) parse(data []byte) error {
+	v, err := uuid.ParseBytes(data)
+	if err != nil {
+		return err
+	}
+	*u = UUID(v)
+	return nil
+}
+
+// Value 返回 16 字节的二进制数据
+//
+// postgres 等原生支持 UUID 的数据库会由 [UUID.DialectValue] 转换成字符串。
+func (u UUID) Value() (driver.Value, error) { return u[:], nil }
+
+func (u UUID) DialectValue(dialect string) (driver.Value, error) {
+	switch dialect {
+	case "postgres", "cockroach":
+		return u.String(), nil
+	default:
+		return u.Value()
+	}
+}
+
+func (u UUID) PrimitiveType() core.PrimitiveType { return UUIDType }
+
+func (u UUID) MarshalText() ([]byte, error) { return []byte(u.String()), nil }
+
+func (u *UUID) UnmarshalText(data []byte) error { return u.parse(data) }
+
+// String 返回 xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx 格式的字符串
+func (u TextUUID) String() string { return UUID(u).String() }
+
+// IsZero 是否为零值
+func (u TextUUID) IsZero() bool { return UUID(u).IsZero() }
+
+func (u *TextUUID) Scan(src any) error { return (*UUID)(u).Scan(src) }
+
+func (u TextUUID) Value() (driver.Value, error) { return u.String(), nil }
+
+func (u TextUUID) PrimitiveType() core.PrimitiveType { return TextUUIDType }
+
+func (u TextUUID) MarshalText() ([]byte, error) { return UUID(u).MarshalText() }
+
+func (u *TextUUID) UnmarshalText(data []byte) error { return (*UUID)(u).UnmarshalText(data) }
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2014-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package types
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+
+	"github.com/issue9/orm/v6/core"
+)
+
+var (
+	_ sql.Scanner         = &UUID{}
+	_ core.DialectValuer  = UUID{}
+	_ core.PrimitiveTyper = &UUID{}
+
+	_ sql.Scanner         = &TextUUID{}
+	_ driver.Valuer       = TextUUID{}
+	_ core.PrimitiveTyper = &TextUUID{}
+)
+
+const uuidString = "0190a6e4-3c5b-7f6e-8a1b-2c3d4e5f6a7b"
+
+func TestUUID(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(core.GetPrimitiveType(reflect.TypeFor[UUID]()), UUIDType).
+		Equal(core.GetPrimitiveType(reflect.TypeFor[TextUUID]()), TextUUIDType)
+
+	u, err := ParseUUID(uuidString)
+	a.NotError(err).Equal(u.String(), uuidString).False(u.IsZero())
+	a.True(UUID{}.IsZero())
+
+	_, err = ParseUUID("invalid")
+	a.Error(err)
+
+	// Scan
+
+	s := UUID{}
+	a.NotError(s.Scan(uuidString)).Equal(s, u)
+
+	s = UUID{}
+	a.NotError(s.Scan([]byte(uuidString))).Equal(s, u)
+
+	s = UUID{}
+	a.NotError(s.Scan(u[:])).Equal(s, u)
+
+	a.NotError(s.Scan(nil)).True(s.IsZero())
+	a.Error(s.Scan(5))
+	a.Error(s.Scan("5"))
+
+	// Value
+
+	v, err := u.Value()
+	a.NotError(err).Equal(v, u[:])
+
+	v, err = u.DialectValue("postgres")
+	a.NotError(err).Equal(v, uuidString)
+
+	v, err = u.DialectValue("mysql")
+	a.NotError(err).Equal(v, u[:])
+
+	// JSON
+
+	data, err := json.Marshal(u)
+	a.NotError(err).Equal(string(data), `"`+uuidString+`"`)
+	s = UUID{}
+	a.NotError(json.Unmarshal(data, &s)).Equal(s, u)
+
+	// TextUUID
+
+	tu := TextUUID{}
+	a.NotError(tu.Scan(u[:])).Equal(tu, TextUUID(u)).Equal(tu.String(), uuidString)
+	v, err = tu.Value()
+	a.NotError(err).Equal(v, uuidString)
+}
+
+func TestNewUUID(t *testing.T) {
+	a := assert.New(t, false)
+
+	u4, err := NewUUIDv4()
+	a.NotError(err).Equal(u4[6]>>4, 4)
+
+	u1, err := NewUUIDv7()
+	a.NotError(err).Equal(u1[6]>>4, 7)
+	u2, err := NewUUIDv7()
+	a.NotError(err).True(u1.String() < u2.String())
+}
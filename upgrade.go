@@ -7,6 +7,7 @@ package orm
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/issue9/orm/v6/core"
 	"github.com/issue9/orm/v6/sqlbuilder"
@@ -19,9 +20,10 @@ import (
 // varchar(50) 和 text 在 sqlite3 是相同的，但是在其它数据库可能是稍微有差别的，
 // 所以 [Upgrader] 并不会主动对数据表进行更新，所有更新还是要手动调用相关的函数。
 type Upgrader struct {
-	model *core.Model
-	err   error
-	ddl   []sqlbuilder.DDLStmt
+	model  *core.Model
+	prefix string
+	err    error
+	ddl    []sqlbuilder.DDLStmt
 
 	e        Engine
 	commit   func() error
@@ -43,7 +45,7 @@ func (db *DB) Upgrade(v TableNamer) (*Upgrader, error) {
 	commit := func() error { return nil }
 	rollback := func() error { return nil }
 
-	if db.Dialect().TransactionalDDL() {
+	if db.transactionalDDL() {
 		tx, err := db.Begin()
 		if err != nil {
 			return nil, err
@@ -55,8 +57,9 @@ func (db *DB) Upgrade(v TableNamer) (*Upgrader, error) {
 	}
 
 	return &Upgrader{
-		model: m,
-		ddl:   make([]sqlbuilder.DDLStmt, 0, 10),
+		model:  m,
+		prefix: db.TablePrefix(),
+		ddl:    make([]sqlbuilder.DDLStmt, 0, 10),
 
 		e:        e,
 		commit:   commit,
@@ -66,6 +69,14 @@ func (db *DB) Upgrade(v TableNamer) (*Upgrader, error) {
 
 func (u *Upgrader) Engine() Engine { return u.e }
 
+// 约束和索引在数据表中的实际名称
+//
+// 与 [DB.Create] 相同，会在 name 之前加上表名，同时将 # 替换为表名前缀。
+// sqlite3 等需要从数据表结构中查找约束的数据库，只能通过实际的名称进行比较。
+func (u *Upgrader) constraintName(name string) string {
+	return strings.ReplaceAll(constraintName(u.model.Name, name), "#", u.prefix)
+}
+
 // Err 返回执行过程中的错误信息
 func (u *Upgrader) Err() error { return u.err }
 
@@ -90,8 +101,11 @@ func (u *Upgrader) AddColumn(name ...string) *Upgrader {
 		}
 
 		sql := sqlbuilder.AddColumn(u.Engine()).
-			Table(u.model.Name).
+			Table(u.model.FullName()).
 			Column(col.Name, col.PrimitiveType, col.AI, col.Nullable, col.HasDefault, col.Default, col.Length...)
+		if len(col.Enums) > 0 {
+			sql.Enums(col.Enums...)
+		}
 		u.ddl = append(u.ddl, sql)
 	}
 
@@ -101,19 +115,119 @@ func (u *Upgrader) AddColumn(name ...string) *Upgrader {
 // DropColumn 删除表中的列
 //
 // 列名可以不存在于表模型，只在数据库中的表包含该列名，就会被删除。
+// 如果表模型中的该列是原生的枚举列，会一并删除其关联的类型。
 func (u *Upgrader) DropColumn(name ...string) *Upgrader {
 	if u.err == nil {
 		for _, n := range name {
-			sql := sqlbuilder.DropColumn(u.Engine()).Table(u.model.Name).Column(n)
+			sql := sqlbuilder.DropColumn(u.Engine()).Table(u.model.FullName()).Column(n)
+			if col := u.model.FindColumn(n); col != nil && sqlbuilder.IsNativeEnum(u.Engine().Dialect(), col) {
+				sql.Enum()
+			}
 			u.ddl = append(u.ddl, sql)
 		}
 	}
 	return u
 }
 
+// RenameColumn 将数据表中的列 old 重命名为 name
+//
+// name 必须存在于表模型中。
+func (u *Upgrader) RenameColumn(old, name string) *Upgrader {
+	if u.err != nil {
+		return u
+	}
+
+	if u.model.FindColumn(name) == nil {
+		u.err = core.ErrColumnNotFound(name)
+		return u
+	}
+
+	sql := sqlbuilder.RenameColumn(u.Engine()).Table(u.model.FullName()).Column(old, name)
+	u.ddl = append(u.ddl, sql)
+	return u
+}
+
+// AlterColumn 根据表模型修改列的定义
+//
+// 包括列的类型、长度、是否可为空以及默认值，列名必须存在于表模型中。
+// 原生类型的枚举列需要通过 [Upgrader.AlterEnum] 修改。
+func (u *Upgrader) AlterColumn(name ...string) *Upgrader {
+	for _, n := range name {
+		if u.err != nil {
+			return u
+		}
+
+		col := u.model.FindColumn(n)
+		if col == nil {
+			u.err = core.ErrColumnNotFound(n)
+			return u
+		}
+
+		if sqlbuilder.IsNativeEnum(u.Engine().Dialect(), col) {
+			u.err = fmt.Errorf("枚举列 %s 需要通过 AlterEnum 修改", col.Name)
+			return u
+		}
+
+		sql := sqlbuilder.AlterColumn(u.Engine()).
+			Table(u.model.FullName()).
+			Column(col.Name, col.PrimitiveType, col.AI, col.Nullable, col.HasDefault, col.Default, col.Length...)
+		if len(col.Enums) > 0 {
+			sql.Enums(col.Enums...)
+		}
+		u.ddl = append(u.ddl, sql)
+	}
+
+	return u
+}
+
+// AlterEnum 根据表模型修改枚举列的可选值
+//
+// 列名必须存在于表模型中，且其类型实现了 [core.Enumer] 接口。
+// 以 CHECK 约束实现的枚举会重建约束，新的取值范围必须包含数据表中已有的值；
+// postgres 的原生类型只会添加新的值，不会删除已有的值。
+func (u *Upgrader) AlterEnum(name ...string) *Upgrader {
+	for _, n := range name {
+		if u.err != nil {
+			return u
+		}
+
+		col := u.model.FindColumn(n)
+		if col == nil {
+			u.err = core.ErrColumnNotFound(n)
+			return u
+		}
+
+		if len(col.Enums) == 0 {
+			u.err = fmt.Errorf("列 %s 不是枚举类型", col.Name)
+			return u
+		}
+
+		sql := sqlbuilder.AlterEnum(u.Engine()).Table(u.model.FullName()).Column(col)
+		u.ddl = append(u.ddl, sql)
+	}
+
+	return u
+}
+
+// RenameTable 将数据表 old 重命名为表模型的名称
+//
+// old 可以以 # 开头表示添加表名前缀，未指定模式时与表模型采用相同的模式。
+// 之后的操作都是针对新的表名，所以一般需要在其它操作之前调用。
+func (u *Upgrader) RenameTable(old string) *Upgrader {
+	if u.err == nil {
+		if u.model.Schema != "" && !strings.Contains(old, ".") {
+			old = u.model.Schema + "." + old
+		}
+		sql := sqlbuilder.RenameTable(u.Engine()).Table(old, u.model.Name)
+		u.ddl = append(u.ddl, sql)
+	}
+	return u
+}
+
 // AddConstraint 添加约束
 //
-// 约束必须存在于 model.constraints 中
+// 约束必须存在于 model.constraints 中，
+// 与 [DB.Create] 相同，实际创建的约束名会加上表名。
 func (u *Upgrader) AddConstraint(name ...string) *Upgrader {
 	if u.err != nil {
 		return u
@@ -127,8 +241,8 @@ LOOP:
 			}
 
 			sql := sqlbuilder.AddConstraint(u.Engine())
-			sql.Table(u.model.Name)
-			sql.FK(fk.Name, fk.Column.Name, fk.RefTableName, fk.RefColName, fk.UpdateRule, fk.DeleteRule)
+			sql.Table(u.model.FullName())
+			sql.FK(u.constraintName(fk.Name), fk.Column.Name, fk.RefTableName, fk.RefColName, fk.UpdateRule, fk.DeleteRule)
 
 			u.ddl = append(u.ddl, sql)
 			continue LOOP
@@ -140,12 +254,12 @@ LOOP:
 			}
 
 			sql := sqlbuilder.AddConstraint(u.Engine())
-			sql.Table(u.model.Name)
+			sql.Table(u.model.FullName())
 			cols := make([]string, 0, len(uu.Columns))
 			for _, col := range uu.Columns {
 				cols = append(cols, col.Name)
 			}
-			sql.Unique(uu.Name, cols...)
+			sql.Unique(u.constraintName(uu.Name), cols...)
 
 			u.ddl = append(u.ddl, sql)
 			continue LOOP
@@ -157,20 +271,20 @@ LOOP:
 			}
 
 			sql := sqlbuilder.AddConstraint(u.Engine())
-			sql.Table(u.model.Name)
-			sql.Check(name, expr)
+			sql.Table(u.model.FullName())
+			sql.Check(u.constraintName(name), expr)
 
 			u.ddl = append(u.ddl, sql)
 			continue LOOP
 		}
 
-		if u.model.PrimaryKey.Name == c {
+		if u.model.PrimaryKey != nil && u.model.PrimaryKey.Name == c {
 			cols := make([]string, 0, len(u.model.PrimaryKey.Columns))
 			for _, col := range u.model.PrimaryKey.Columns {
 				cols = append(cols, col.Name)
 			}
 
-			sql := sqlbuilder.AddConstraint(u.Engine()).Table(u.model.Name).PK(c, cols...)
+			sql := sqlbuilder.AddConstraint(u.Engine()).Table(u.model.FullName()).PK(u.constraintName(c), cols...)
 			u.ddl = append(u.ddl, sql)
 		}
 	}
@@ -179,26 +293,44 @@ LOOP:
 }
 
 // DropConstraint 删除约束
+//
+// 与 [Upgrader.AddConstraint] 相同，conts 为模型中的约束名，
+// 实际删除的约束名会加上表名。
+//
+// NOTE: 此前的版本直接使用 conts 作为约束名，需要删除其它名称的约束，
+// 可以使用 [sqlbuilder.DropConstraintStmt]。
 func (u *Upgrader) DropConstraint(conts ...string) *Upgrader {
-	if u.err == nil {
-		for _, n := range conts {
-			sql := sqlbuilder.DropConstraint(u.Engine()).Table(u.model.Name).Constraint(n)
-			u.ddl = append(u.ddl, sql)
-		}
+	for _, n := range conts {
+		u.dropConstraint(u.constraintName(n))
 	}
 	return u
 }
 
+func (u *Upgrader) dropConstraint(name string) {
+	if u.err == nil {
+		sql := sqlbuilder.DropConstraint(u.Engine()).Table(u.model.FullName()).Constraint(name)
+		u.ddl = append(u.ddl, sql)
+	}
+}
+
 // DropPK 删除主键约束
+//
+// 与 [Upgrader.DropConstraint] 相同，实际删除的约束名会加上表名。
 func (u *Upgrader) DropPK(name string) *Upgrader {
+	u.dropPK(u.constraintName(name))
+	return u
+}
+
+func (u *Upgrader) dropPK(name string) {
 	if u.err == nil {
-		sql := sqlbuilder.DropConstraint(u.Engine()).Table(u.model.Name).PK(name)
+		sql := sqlbuilder.DropConstraint(u.Engine()).Table(u.model.FullName()).PK(name)
 		u.ddl = append(u.ddl, sql)
 	}
-	return u
 }
 
 // AddIndex 添加索引信息
+//
+// 与 [DB.Create] 相同，实际创建的索引名会加上表名。
 func (u *Upgrader) AddIndex(name ...string) *Upgrader {
 	if u.Err() != nil {
 		return u
@@ -206,7 +338,7 @@ func (u *Upgrader) AddIndex(name ...string) *Upgrader {
 
 	for _, index := range name {
 		sql := sqlbuilder.CreateIndex(u.Engine())
-		sql.Table(u.model.Name)
+		sql.Table(u.model.FullName())
 
 		for _, i := range u.model.Indexes {
 			if i.Name != index {
@@ -218,7 +350,7 @@ func (u *Upgrader) AddIndex(name ...string) *Upgrader {
 				cs = append(cs, c.Name)
 			}
 
-			sql.Name(i.Name)
+			sql.Name(u.constraintName(i.Name))
 			sql.Columns(cs...)
 		}
 
@@ -229,11 +361,53 @@ func (u *Upgrader) AddIndex(name ...string) *Upgrader {
 }
 
 // DropIndex 删除索引
+//
+// 与 [Upgrader.AddIndex] 相同，name 为模型中的索引名，
+// 实际删除的索引名会加上表名。
+//
+// NOTE: 此前的版本直接使用 name 作为索引名，需要删除其它名称的索引，
+// 可以使用 [sqlbuilder.DropIndexStmt]。
 func (u *Upgrader) DropIndex(name ...string) *Upgrader {
+	for _, index := range name {
+		u.dropIndex(u.constraintName(index))
+	}
+	return u
+}
+
+func (u *Upgrader) dropIndex(name string) {
 	if u.Err() == nil {
-		for _, index := range name {
-			sql := sqlbuilder.DropIndex(u.Engine()).Table(u.model.Name).Name(index)
-			u.ddl = append(u.ddl, sql)
+		sql := sqlbuilder.DropIndex(u.Engine()).Table(u.model.FullName()).Name(name)
+		u.ddl = append(u.ddl, sql)
+	}
+}
+
+// Changes 添加由 [DB.Diff] 返回的操作
+//
+// [CreateTable] 类型的操作无法由 [Upgrader] 完成，会返回错误。
+// 删除类操作的 [Change.Name] 为数据表中的实际名称，会原样删除，不会再加上表名。
+func (u *Upgrader) Changes(c ...*Change) *Upgrader {
+	for _, cc := range c {
+		if u.err != nil {
+			return u
+		}
+
+		switch cc.Type {
+		case AddColumn:
+			u.AddColumn(cc.Name)
+		case DropColumn:
+			u.DropColumn(cc.Name)
+		case AddConstraint:
+			u.AddConstraint(cc.Name)
+		case DropConstraint:
+			u.dropConstraint(cc.Name)
+		case DropPK:
+			u.dropPK(cc.Name)
+		case AddIndex:
+			u.AddIndex(cc.Name)
+		case DropIndex:
+			u.dropIndex(cc.Name)
+		default:
+			u.err = fmt.Errorf("无法处理的操作 %s", cc)
 		}
 	}
 	return u
@@ -37,9 +37,9 @@ func TestUpgrader(t *testing.T) {
 			Column("name", core.String, false, false, false, nil, 50).
 			Column("username", core.String, false, false, false, nil, 50).
 			Column("created", core.Int64, false, false, false, nil).
-			Index(core.IndexDefault, "i_name", "name").
-			Unique("u_username", "username").
-			Check("chk_id", "id>0").
+			Index(core.IndexDefault, "upgrades_i_name", "name").
+			Unique("upgrades_u_username", "username").
+			Check("upgrades_chk_id", "id>0").
 			Table((&u2{}).TableName())
 		t.NotError(sql.Exec())
 
@@ -62,3 +62,163 @@ func TestUpgrader(t *testing.T) {
 		t.NotError(err, "%s@%s", err, t.DriverName)
 	})
 }
+
+func TestUpgrader_Rename(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		sql := t.DB.SQLBuilder().CreateTable().
+			Column("id", core.Int64, false, false, false, nil).
+			Column("name", core.String, false, false, false, nil, 20).
+			Column("username", core.String, false, false, false, nil, 50).
+			Column("uname", core.String, false, false, false, nil, 50).
+			Column("modified", core.Int64, false, true, false, nil).
+			Column("created", core.String, false, true, false, nil).
+			Table("#upgrades_old")
+		t.NotError(sql.Exec())
+
+		defer func() {
+			t.NotError(t.DB.Drop(&u2{}))
+		}()
+
+		u, err := t.DB.Upgrade(&u2{})
+		t.NotError(err).NotNil(u)
+
+		err = u.RenameTable("#upgrades_old").
+			DropColumn("username").
+			RenameColumn("uname", "username").
+			AlterColumn("name", "modified").
+			Do()
+		t.NotError(err, "%s@%s", err, t.DriverName)
+
+		// modified 的默认值为 0，name 的长度为 50
+		_, err = t.DB.Insert(&u2{ID: 1, Name: "12345678901234567890123", UserName: "u1"})
+		t.NotError(err)
+		obj := &u2{UserName: "u1"}
+		found, err := t.DB.Select(obj)
+		t.NotError(err).True(found).Equal(obj.Modified, 0).Equal(obj.Name, "12345678901234567890123")
+
+		u, err = t.DB.Upgrade(&u2{})
+		t.NotError(err).NotNil(u)
+		t.Error(u.RenameColumn("uname", "not_exists").Err())
+
+		u, err = t.DB.Upgrade(&u2{})
+		t.NotError(err).NotNil(u)
+		t.Error(u.AlterColumn("not_exists").Err())
+	})
+}
+
+type (
+	enumState string
+	enumLevel int8
+
+	u3 struct {
+		ID    int64     `orm:"name(id);pk"`
+		State enumState `orm:"name(state);len(20);default(draft)"`
+		Level enumLevel `orm:"name(level);default(1)"`
+	}
+)
+
+func (enumState) Enums() []any { return []any{enumState("draft"), enumState("published")} }
+
+func (enumLevel) Enums() []any { return []any{enumLevel(1), enumLevel(2)} }
+
+func (u *u3) TableName() string { return "upgrade_enums" }
+
+func TestUpgrader_AlterEnum(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "")
+
+	suite.Run(func(t *test.Driver) {
+		sql := t.DB.SQLBuilder().CreateTable().
+			Column("id", core.Int64, false, false, false, nil).
+			Columns(&core.Column{Name: "state", PrimitiveType: core.String, Length: []int{20}, Enums: []any{"draft"}}).
+			PK("upgrade_enums_pk", "id").
+			Table("#upgrade_enums")
+		t.NotError(sql.Exec())
+
+		defer func() {
+			t.NotError(t.DB.Drop(&u3{}))
+		}()
+
+		_, err := t.DB.Insert(&u3{ID: 1, State: "published", Level: 1})
+		t.Error(err)
+
+		u, err := t.DB.Upgrade(&u3{})
+		t.NotError(err).NotNil(u)
+		err = u.AlterEnum("state").AddColumn("level").Do()
+		t.NotError(err, "%s@%s", err, t.DriverName)
+
+		_, err = t.DB.Insert(&u3{ID: 1, State: "published", Level: 2})
+		t.NotError(err)
+		_, err = t.DB.Insert(&u3{ID: 2, State: "deleted", Level: 1})
+		t.Error(err)
+		_, err = t.DB.Insert(&u3{ID: 3, State: "draft", Level: 3})
+		t.Error(err)
+
+		obj := &u3{ID: 1}
+		found, err := t.DB.Select(obj)
+		t.NotError(err).True(found).
+			Equal(obj.State, enumState("published")).
+			Equal(obj.Level, enumLevel(2))
+
+		// 由 AddColumn 添加的约束
+		u, err = t.DB.Upgrade(&u3{})
+		t.NotError(err).NotNil(u)
+		err = u.AlterEnum("level").Do()
+		t.NotError(err, "%s@%s", err, t.DriverName)
+		_, err = t.DB.Insert(&u3{ID: 4, State: "draft", Level: 3})
+		t.Error(err)
+
+		u, err = t.DB.Upgrade(&u3{})
+		t.NotError(err).NotNil(u)
+		t.Error(u.AlterEnum("id").Err())
+
+		u, err = t.DB.Upgrade(&u3{})
+		t.NotError(err).NotNil(u)
+		t.Error(u.AlterEnum("not_exists").Err())
+	})
+}
+
+type (
+	u4 struct {
+		ID      int64     `orm:"name(id);pk"`
+		Status  enumLevel `orm:"name(status);default(1)"`
+		TStatus enumLevel `orm:"name(t_status);default(1)"`
+	}
+)
+
+func (u *u4) TableName() string { return "t" }
+
+// 约束名相互包含的情况
+func TestUpgrader_AlterEnum_suffix(t *testing.T) {
+	a := assert.New(t, false)
+	suite := test.NewSuite(a, "p_")
+
+	suite.Run(func(t *test.Driver) {
+		sql := t.DB.SQLBuilder().CreateTable().
+			Column("id", core.Int64, false, false, false, nil).
+			Columns(
+				&core.Column{Name: "status", PrimitiveType: core.Int8, HasDefault: true, Default: 1, Enums: []any{1}},
+				&core.Column{Name: "t_status", PrimitiveType: core.Int8, HasDefault: true, Default: 1, Enums: []any{1}},
+			).
+			PK("t_pk", "id").
+			Table("#t")
+		t.NotError(sql.Exec())
+
+		defer func() {
+			t.NotError(t.DB.Drop(&u4{}))
+		}()
+
+		u, err := t.DB.Upgrade(&u4{})
+		t.NotError(err).NotNil(u)
+		err = u.AlterEnum("status").Do()
+		t.NotError(err, "%s@%s", err, t.DriverName)
+
+		_, err = t.DB.Insert(&u4{ID: 1, Status: 2, TStatus: 1})
+		t.NotError(err)
+		_, err = t.DB.Insert(&u4{ID: 2, Status: 1, TStatus: 2})
+		t.Error(err)
+	})
+}
@@ -49,7 +49,7 @@ func (stmt *WhereStmt) Delete(v TableNamer) (sql.Result, error) {
 		return nil, fmt.Errorf("模型 %s 的类型是视图，无法从其中删除数据", m.Name)
 	}
 
-	return stmt.WhereStmt().Delete(stmt.engine).Table(m.Name).Exec()
+	return stmt.WhereStmt().Delete(stmt.engine).Table(m.FullName()).Exec()
 }
 
 // Update 将 v 中内容更新到符合条件的行中
@@ -86,7 +86,7 @@ func (stmt *WhereStmt) Select(strict bool, v any) (int, error) {
 
 	return stmt.WhereStmt().Select(stmt.engine).
 		Column("*").
-		From(m.Name).
+		From(m.FullName()).
 		QueryObject(strict, v)
 }
 
@@ -101,6 +101,6 @@ func (stmt *WhereStmt) Count(v TableNamer) (int64, error) {
 
 	return stmt.WhereStmt().Select(stmt.engine).
 		Count("count(*) as cnt").
-		From(m.Name).
+		From(m.FullName()).
 		QueryInt("cnt")
 }